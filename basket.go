@@ -0,0 +1,103 @@
+package go_monobank
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// LineTotal returns basket item total in minor units.
+// Explicit Total wins; otherwise it is Sum*Qty with item Discounts applied.
+func (i BasketItem) LineTotal() int64 {
+	if i.Total > 0 {
+		return i.Total
+	}
+	return applyDiscounts(float64(i.Sum)*i.Qty, i.Discounts)
+}
+
+// BasketTotal returns total of BasketOrder in minor units with basket-level Discounts applied.
+// It returns 0 when basket is empty.
+func (m *MerchantPaymInfo) BasketTotal() int64 {
+	if m == nil || len(m.BasketOrder) == 0 {
+		return 0
+	}
+	var total int64
+	for _, item := range m.BasketOrder {
+		total += item.LineTotal()
+	}
+	return applyDiscounts(float64(total), m.Discounts)
+}
+
+// ValidateBasket checks basketOrder items and verifies that basket total
+// equals PaymentData.Amount. Requests without basketOrder are always valid.
+func (r *Request) ValidateBasket() error {
+	info := r.GetMerchantPaymInfo()
+	if info == nil || len(info.BasketOrder) == 0 {
+		return nil
+	}
+	if err := validateDiscounts("discounts", info.Discounts); err != nil {
+		return err
+	}
+	if err := validateBasketItems(info.BasketOrder); err != nil {
+		return fmt.Errorf("basketOrder: %w", err)
+	}
+	if total, amount := info.BasketTotal(), r.GetAmount(); total != amount {
+		return fmt.Errorf("basketOrder total %d does not match amount %d", total, amount)
+	}
+	return nil
+}
+
+func validateBasketItems(items []BasketItem) error {
+	for i, item := range items {
+		if strings.TrimSpace(item.Name) == "" {
+			return fmt.Errorf("items[%d].name is required", i)
+		}
+		if item.Qty <= 0 {
+			return fmt.Errorf("items[%d].qty must be > 0", i)
+		}
+		if item.Sum < 0 {
+			return fmt.Errorf("items[%d].sum must be >= 0", i)
+		}
+		if item.Total < 0 {
+			return fmt.Errorf("items[%d].total must be >= 0", i)
+		}
+		if err := validateDiscounts(fmt.Sprintf("items[%d].discounts", i), item.Discounts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateDiscounts(field string, discounts []Discount) error {
+	for i, d := range discounts {
+		switch d.Type {
+		case DiscountTypeDiscount, DiscountTypeExtraCharge:
+		default:
+			return fmt.Errorf("%s[%d].type must be DISCOUNT or EXTRA_CHARGE", field, i)
+		}
+		switch d.Mode {
+		case DiscountModePercent, DiscountModeValue:
+		default:
+			return fmt.Errorf("%s[%d].mode must be PERCENT or VALUE", field, i)
+		}
+		if d.Value < 0 {
+			return fmt.Errorf("%s[%d].value must be >= 0", field, i)
+		}
+	}
+	return nil
+}
+
+func applyDiscounts(amount float64, discounts []Discount) int64 {
+	for _, d := range discounts {
+		delta := d.Value
+		if d.Mode == DiscountModePercent {
+			delta = amount * d.Value / 100
+		}
+		if d.Type == DiscountTypeExtraCharge {
+			amount += delta
+		} else {
+			amount -= delta
+		}
+	}
+	return int64(math.Round(amount))
+}
@@ -0,0 +1,125 @@
+package go_monobank
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestBasketTotalAppliesDiscounts(t *testing.T) {
+	t.Parallel()
+
+	info := &MerchantPaymInfo{
+		BasketOrder: []BasketItem{
+			{Name: "Coffee", Qty: 2, Sum: 5000, Code: "coffee"},
+			{
+				Name: "Cake", Qty: 1, Sum: 10000, Code: "cake",
+				Discounts: []Discount{{Type: DiscountTypeDiscount, Mode: DiscountModePercent, Value: 10}},
+			},
+			{Name: "Delivery", Qty: 1, Sum: 0, Total: 3000, Code: "delivery"},
+		},
+		Discounts: []Discount{{Type: DiscountTypeDiscount, Mode: DiscountModeValue, Value: 500}},
+	}
+
+	// 10000 + 9000 + 3000 - 500
+	if got := info.BasketTotal(); got != 21500 {
+		t.Fatalf("BasketTotal() = %d, want 21500", got)
+	}
+
+	var nilInfo *MerchantPaymInfo
+	if got := nilInfo.BasketTotal(); got != 0 {
+		t.Fatalf("nil BasketTotal() = %d, want 0", got)
+	}
+}
+
+func TestValidateBasket(t *testing.T) {
+	t.Parallel()
+
+	coffee := BasketItem{Name: "Coffee", Qty: 2, Sum: 5000, Code: "coffee"}
+	tests := []struct {
+		name    string
+		request *Request
+		wantErr bool
+	}{
+		{name: "no basket", request: NewRequest().WithAmount(100)},
+		{name: "matching total", request: NewRequest().WithAmount(10000).WithBasketOrder(coffee)},
+		{name: "mismatched total", request: NewRequest().WithAmount(9999).WithBasketOrder(coffee), wantErr: true},
+		{name: "missing name", request: NewRequest().WithAmount(100).AddBasketItem(BasketItem{Qty: 1, Sum: 100}), wantErr: true},
+		{
+			name: "bad discount mode",
+			request: NewRequest().WithAmount(10000).WithBasketOrder(coffee).
+				WithBasketDiscounts(Discount{Type: DiscountTypeDiscount, Mode: "HALF"}),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(
+			tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := tc.request.ValidateBasket()
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("ValidateBasket() error = %v, wantErr %v", err, tc.wantErr)
+				}
+			},
+		)
+	}
+}
+
+func TestPaymentRejectsBasketTotalMismatch(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	request := NewRequest().
+		WithCardToken("card-token").
+		WithAmount(100).
+		WithInitiationKind(InitiationMerchant).
+		AddBasketItem(BasketItem{Name: "Coffee", Qty: 1, Sum: 200, Code: "coffee"})
+
+	_, err := client.Payment(request, DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestVerificationDryRunSendsBasketOrder(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	request := NewRequest().
+		WithAmount(15000).
+		AddBasketItem(
+			BasketItem{
+				Name: "Coffee", Qty: 3, Sum: 5000, Code: "coffee", Unit: "cup", Tax: []int{1}, Uktzed: "0901",
+			},
+		)
+
+	var payload any
+	_, err := client.Verification(request, DryRun(func(_ string, gotPayload any) { payload = gotPayload }))
+	if err != nil {
+		t.Fatalf("Verification() unexpected error: %v", err)
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal dry-run payload: %v", err)
+	}
+
+	var got struct {
+		MerchantPaymInfo struct {
+			BasketOrder []BasketItem `json:"basketOrder"`
+		} `json:"merchantPaymInfo"`
+	}
+	if err = json.Unmarshal(payloadJSON, &got); err != nil {
+		t.Fatalf("unmarshal dry-run payload: %v", err)
+	}
+	if len(got.MerchantPaymInfo.BasketOrder) != 1 {
+		t.Fatalf("unexpected basketOrder: %s", payloadJSON)
+	}
+	item := got.MerchantPaymInfo.BasketOrder[0]
+	if item.Unit != "cup" || item.Uktzed != "0901" || len(item.Tax) != 1 {
+		t.Fatalf("unexpected basket item: %+v", item)
+	}
+}
@@ -0,0 +1,171 @@
+package go_monobank
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stremovskyy/recorder"
+)
+
+func TestCancelRequiresInvoiceID(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+
+	_, err := client.Cancel(NewRequest().WithAmount(100), DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestCancelRejectsInvalidItems(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	request := NewRequest().
+		WithInvoiceID("inv-1").
+		WithAmount(100).
+		AddItem(BasketItem{Name: "Coffee", Qty: 0, Sum: 100, Code: "c-1"})
+
+	_, err := client.Cancel(request, DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestCancelDryRunPartialRefundPayload(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	request := NewRequest().
+		WithInvoiceID("inv-1").
+		WithExtRef("refund-1").
+		WithAmount(150).
+		AddItem(BasketItem{Name: "Coffee", Qty: 1.5, Sum: 100, Code: "c-1"})
+
+	var endpoint string
+	var payload any
+	_, err := client.Cancel(
+		request,
+		DryRun(
+			func(gotEndpoint string, gotPayload any) {
+				endpoint = gotEndpoint
+				payload = gotPayload
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Cancel() unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(endpoint, "/api/merchant/invoice/cancel") {
+		t.Fatalf("unexpected dry-run endpoint: %s", endpoint)
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal dry-run payload: %v", err)
+	}
+
+	var got struct {
+		InvoiceID string       `json:"invoiceId"`
+		ExtRef    string       `json:"extRef"`
+		Amount    int64        `json:"amount"`
+		Items     []BasketItem `json:"items"`
+	}
+	if err = json.Unmarshal(payloadJSON, &got); err != nil {
+		t.Fatalf("unmarshal dry-run payload: %v", err)
+	}
+	if got.InvoiceID != "inv-1" || got.ExtRef != "refund-1" || got.Amount != 150 {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+	if len(got.Items) != 1 || got.Items[0].Qty != 1.5 {
+		t.Fatalf("unexpected items: %+v", got.Items)
+	}
+}
+
+func TestCancelFullRefundOmitsAmount(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+
+	var payload any
+	_, err := client.Cancel(
+		NewRequest().WithInvoiceID("inv-1"),
+		DryRun(func(_ string, gotPayload any) { payload = gotPayload }),
+	)
+	if err != nil {
+		t.Fatalf("Cancel() unexpected error: %v", err)
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal dry-run payload: %v", err)
+	}
+	if strings.Contains(string(payloadJSON), `"amount"`) {
+		t.Fatalf("amount must be omitted for full cancellation: %s", payloadJSON)
+	}
+}
+
+func TestCancelDecodesResponseAndRecords(t *testing.T) {
+	t.Parallel()
+
+	storage := &captureStorage{}
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Fatalf("unexpected method: %s", r.Method)
+				}
+				if r.URL.Path != "/api/merchant/invoice/cancel" {
+					t.Fatalf("unexpected path: %s", r.URL.Path)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if !strings.Contains(string(body), `"extRef":"refund-1"`) {
+					t.Fatalf("unexpected body: %s", body)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"status":"processing","createdDate":"2026-02-26T10:00:00Z","modifiedDate":"2026-02-26T10:00:01Z"}`))
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRecorder(recorder.New(storage)),
+		WithToken("merchant-token"),
+	)
+
+	resp, err := client.Cancel(NewRequest().WithInvoiceID("inv-1").WithExtRef("refund-1").WithAmount(50))
+	if err != nil {
+		t.Fatalf("Cancel() unexpected error: %v", err)
+	}
+	if !resp.IsPending() || resp.IsFinal() {
+		t.Fatalf("unexpected status helpers for %+v", resp)
+	}
+
+	item := resp.CancelItem(50, CurrencyUAH, "refund-1")
+	if item.Status != InvoiceProcessing || item.Amount != 50 || item.ExtRef == nil || *item.ExtRef != "refund-1" {
+		t.Fatalf("unexpected cancel item: %+v", item)
+	}
+	if !item.ModifiedDate.Equal(resp.ModifiedDate) {
+		t.Fatalf("modifiedDate mismatch: %v vs %v", item.ModifiedDate, resp.ModifiedDate)
+	}
+
+	records := storage.snapshot()
+	if len(records) != 2 {
+		t.Fatalf("expected request+response records, got %d", len(records))
+	}
+	if records[0].Tags["operation"] != "cancel" {
+		t.Fatalf("expected operation=cancel, got %q", records[0].Tags["operation"])
+	}
+	if records[0].Tags["ext_ref"] != "refund-1" {
+		t.Fatalf("expected ext_ref tag, got %q", records[0].Tags["ext_ref"])
+	}
+}
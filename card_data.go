@@ -0,0 +1,91 @@
+package go_monobank
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CardData is raw card data for PaymentDirect.
+//
+// It is only allowed for PCI DSS-certified merchants. The SDK never logs or
+// records it in raw form; String and Masked return a PCI-safe representation.
+type CardData struct {
+	PAN string `json:"pan"`
+	// Exp is card expiry in MMYY format.
+	Exp string `json:"exp"`
+	CVV string `json:"cvv"`
+}
+
+// Masked returns a copy with PAN truncated to first 6 / last 4 digits
+// and expiry/CVV replaced with asterisks.
+func (c CardData) Masked() CardData {
+	return CardData{
+		PAN: maskPAN(c.PAN),
+		Exp: strings.Repeat("*", len(c.Exp)),
+		CVV: strings.Repeat("*", len(c.CVV)),
+	}
+}
+
+// String implements fmt.Stringer without exposing card data.
+func (c CardData) String() string {
+	return fmt.Sprintf("CardData{PAN:%s}", maskPAN(c.PAN))
+}
+
+// GoString implements fmt.GoStringer without exposing card data.
+func (c CardData) GoString() string {
+	return c.String()
+}
+
+// Validate checks PAN (digits, length, Luhn), expiry (MMYY) and CVV format.
+func (c CardData) Validate() error {
+	pan := strings.TrimSpace(c.PAN)
+	if len(pan) < 12 || len(pan) > 19 || !isDigits(pan) {
+		return fmt.Errorf("cardData.pan must be 12..19 digits")
+	}
+	if !luhnValid(pan) {
+		return fmt.Errorf("cardData.pan checksum is invalid")
+	}
+	exp := strings.TrimSpace(c.Exp)
+	if len(exp) != 4 || !isDigits(exp) || exp[:2] < "01" || exp[:2] > "12" {
+		return fmt.Errorf("cardData.exp must be in MMYY format")
+	}
+	cvv := strings.TrimSpace(c.CVV)
+	if len(cvv) < 3 || len(cvv) > 4 || !isDigits(cvv) {
+		return fmt.Errorf("cardData.cvv must be 3..4 digits")
+	}
+	return nil
+}
+
+func maskPAN(pan string) string {
+	pan = strings.TrimSpace(pan)
+	if len(pan) < 12 {
+		return strings.Repeat("*", len(pan))
+	}
+	return pan[:6] + strings.Repeat("*", len(pan)-10) + pan[len(pan)-4:]
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,337 @@
+package go_monobank
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stremovskyy/recorder"
+
+	"github.com/stremovskyy/go-monobank/consts"
+)
+
+// Cassette is an in-memory recorder.Storage that keeps records in save order,
+// so traffic captured via WithRecorder(recorder.New(cassette)) can be replayed
+// by ReplayTransport or written to a JSONL file.
+//
+// JSONL format is one record per line:
+//
+//	{"type":"request","requestId":"...","tags":{"method":"GET","path":"/api/merchant/invoice/status",...},"payload":{...}}
+//
+// payload holds the raw JSON payload, or a JSON string when payload is not JSON.
+type Cassette struct {
+	mu      sync.RWMutex
+	records []recorder.Record
+}
+
+type cassetteLine struct {
+	Type      recorder.RecordType `json:"type"`
+	RequestID string              `json:"requestId"`
+	PrimaryID *string             `json:"primaryId,omitempty"`
+	Tags      map[string]string   `json:"tags,omitempty"`
+	Payload   json.RawMessage     `json:"payload"`
+}
+
+// NewCassette creates cassette holding records (e.g. exported from another storage).
+func NewCassette(records ...recorder.Record) *Cassette {
+	c := &Cassette{}
+	for _, record := range records {
+		c.records = append(c.records, cloneRecord(record))
+	}
+	return c
+}
+
+// LoadCassette reads JSONL file written by Cassette.WriteFile.
+func LoadCassette(path string) (*Cassette, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: open %s: %w", path, err)
+	}
+	defer f.Close()
+	return ReadCassette(f)
+}
+
+// ReadCassette reads JSONL records from r. Empty lines are skipped.
+func ReadCassette(r io.Reader) (*Cassette, error) {
+	c := &Cassette{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var line cassetteLine
+		if err := json.Unmarshal(raw, &line); err != nil {
+			return nil, &DecodeError{Op: "cassette", Msg: fmt.Sprintf("line %d", n), Body: trimBody(raw, 4096), Cause: err}
+		}
+		c.records = append(c.records, recorder.Record{
+			Type:      line.Type,
+			PrimaryID: line.PrimaryID,
+			RequestID: line.RequestID,
+			Payload:   decodeCassettePayload(line.Payload),
+			Tags:      line.Tags,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cassette: read: %w", err)
+	}
+	return c, nil
+}
+
+// CassetteFromStorage loads SDK traffic recorded into any recorder.Storage (e.g. the one
+// passed to WithRecorder), so it can be replayed with NewReplayTransport.
+//
+// recorder.Storage can neither list records nor return their tags, so records are found
+// by the "gateway:monobank" tag every SDK record carries, and method, path and status_code
+// tags are restored with FindByTag lookups over API paths and HTTP status codes.
+// Interactions keep FindByTag order; use ReplayLenient when storage does not return request
+// IDs in recording order. Requests without a recorded response are skipped.
+func CassetteFromStorage(ctx context.Context, storage recorder.Storage) (*Cassette, error) {
+	if storage == nil {
+		return nil, &ValidationError{Op: "cassette", Msg: "storage is nil"}
+	}
+	if cassette, ok := storage.(*Cassette); ok {
+		return NewCassette(cassette.Records()...), nil
+	}
+
+	ids, err := storage.FindByTag(ctx, "gateway:monobank")
+	if err != nil {
+		return nil, fmt.Errorf("cassette: find records: %w", err)
+	}
+	methods, err := findTagValues(ctx, storage, "method", cassetteMethods)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := findTagValues(ctx, storage, "path", cassettePaths)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := findTagValues(ctx, storage, "status_code", cassetteStatusCodes())
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cassette{}
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		response, err := storage.Load(ctx, recorder.RecordTypeResponse, id)
+		if err != nil || len(response) == 0 {
+			logger.Debug("Cassette: request_id=%s has no response, skipped", id)
+			continue
+		}
+		request, err := storage.Load(ctx, recorder.RecordTypeRequest, id)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: load request %s: %w", id, err)
+		}
+
+		tags := map[string]string{"gateway": "monobank", "method": methods[id], "path": paths[id]}
+		c.records = append(c.records, recorder.Record{Type: recorder.RecordTypeRequest, RequestID: id, Payload: request, Tags: tags})
+
+		responseTags := map[string]string{"gateway": "monobank", "method": methods[id], "path": paths[id]}
+		if code := codes[id]; code != "" {
+			responseTags["status_code"] = code
+		}
+		c.records = append(c.records, recorder.Record{Type: recorder.RecordTypeResponse, RequestID: id, Payload: response, Tags: responseTags})
+	}
+	return c, nil
+}
+
+var (
+	cassetteMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	cassettePaths   = []string{
+		consts.PathInvoiceCreate,
+		consts.PathInvoiceStatus,
+		consts.PathInvoiceCancel,
+		consts.PathInvoiceFinalize,
+		consts.PathInvoiceRemove,
+		consts.PathInvoicePaymentDirect,
+		consts.PathInvoiceFiscalChecks,
+		consts.PathInvoicePaymentInfo,
+		consts.PathWallet,
+		consts.PathWalletPayment,
+		consts.PathWalletCard,
+		consts.PathPubKey,
+		consts.PathStatement,
+		consts.PathMerchantDetails,
+		consts.PathSubMerchantList,
+		consts.PathEmployeeList,
+		consts.PathSplitReceiverList,
+		consts.PathQRList,
+		consts.PathQRDetails,
+		consts.PathQRResetAmount,
+	}
+)
+
+// cassetteStatusCodes returns status codes known to net/http.
+func cassetteStatusCodes() []string {
+	var codes []string
+	for code := 100; code < 600; code++ {
+		if http.StatusText(code) != "" {
+			codes = append(codes, strconv.Itoa(code))
+		}
+	}
+	return codes
+}
+
+// findTagValues maps request IDs to the value of tag key they were found by.
+func findTagValues(ctx context.Context, storage recorder.Storage, key string, values []string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, value := range values {
+		ids, err := storage.FindByTag(ctx, key+":"+value)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: find %s:%s: %w", key, value, err)
+		}
+		for _, id := range ids {
+			out[id] = value
+		}
+	}
+	return out, nil
+}
+
+// WriteFile writes records as JSONL, replacing file atomically.
+func (c *Cassette) WriteFile(path string) error {
+	var buf bytes.Buffer
+	if err := c.Write(&buf); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cassette: create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cassette: write %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cassette: close %s: %w", tmp.Name(), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("cassette: rename to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Write writes records to w as JSONL.
+func (c *Cassette) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, record := range c.Records() {
+		line := cassetteLine{
+			Type:      record.Type,
+			RequestID: record.RequestID,
+			PrimaryID: record.PrimaryID,
+			Tags:      record.Tags,
+			Payload:   encodeCassettePayload(record.Payload),
+		}
+		if err := enc.Encode(line); err != nil {
+			return &EncodeError{Op: "cassette", Msg: "encode record " + record.RequestID, Cause: err}
+		}
+	}
+	return nil
+}
+
+// Records returns a copy of stored records in save order.
+func (c *Cassette) Records() []recorder.Record {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]recorder.Record, 0, len(c.records))
+	for _, record := range c.records {
+		out = append(out, cloneRecord(record))
+	}
+	return out
+}
+
+// Save implements recorder.Storage.
+func (c *Cassette) Save(_ context.Context, record recorder.Record) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records = append(c.records, cloneRecord(record))
+	return nil
+}
+
+// Load implements recorder.Storage: it returns payload of the last record of recordType with requestID.
+func (c *Cassette) Load(_ context.Context, recordType recorder.RecordType, requestID string) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for i := len(c.records) - 1; i >= 0; i-- {
+		record := c.records[i]
+		if record.Type == recordType && record.RequestID == requestID {
+			return append([]byte(nil), record.Payload...), nil
+		}
+	}
+	return nil, fmt.Errorf("cassette: %s record %q not found", recordType, requestID)
+}
+
+// FindByTag implements recorder.Storage. tag is "key:value" (e.g. "invoice_id:inv-1");
+// it returns request IDs of matching records in save order.
+func (c *Cassette) FindByTag(_ context.Context, tag string) ([]string, error) {
+	key, value, ok := strings.Cut(tag, ":")
+	if !ok || key == "" {
+		return nil, fmt.Errorf("cassette: tag must be key:value, got %q", tag)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, record := range c.records {
+		if v, exists := record.Tags[key]; !exists || v != value {
+			continue
+		}
+		if _, dup := seen[record.RequestID]; dup {
+			continue
+		}
+		seen[record.RequestID] = struct{}{}
+		ids = append(ids, record.RequestID)
+	}
+	return ids, nil
+}
+
+func cloneRecord(record recorder.Record) recorder.Record {
+	record.Payload = append([]byte(nil), record.Payload...)
+	if record.Tags != nil {
+		tags := make(map[string]string, len(record.Tags))
+		for k, v := range record.Tags {
+			tags[k] = v
+		}
+		record.Tags = tags
+	}
+	return record
+}
+
+func encodeCassettePayload(payload []byte) json.RawMessage {
+	if len(payload) > 0 && json.Valid(payload) && !bytes.HasPrefix(bytes.TrimSpace(payload), []byte(`"`)) {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, payload); err == nil {
+			return compact.Bytes()
+		}
+	}
+	encoded, _ := json.Marshal(string(payload))
+	return encoded
+}
+
+func decodeCassettePayload(raw json.RawMessage) []byte {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return []byte(text)
+	}
+	return append([]byte(nil), raw...)
+}
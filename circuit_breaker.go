@@ -0,0 +1,174 @@
+package go_monobank
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitOpenTimeout      = 30 * time.Second
+)
+
+// CircuitState is a state of per-endpoint circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests with ErrCircuitOpen without calling API.
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe request through after OpenTimeout.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures circuit breaker enabled by WithCircuitBreaker.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive ErrTransport/ErrServerError
+	// failures of one endpoint that opens its circuit. Default 5.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a probe request is allowed. Default 30s.
+	OpenTimeout time.Duration
+	// OnStateChange is called on every state transition (e.g. for alerting).
+	// It is called synchronously and must not block.
+	OnStateChange func(endpoint string, from, to CircuitState)
+}
+
+type circuitOutcome int
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	// circuitIgnored releases a probe without changing state (e.g. caller canceled).
+	circuitIgnored
+)
+
+type circuitBreaker struct {
+	threshold     int
+	openTimeout   time.Duration
+	onStateChange func(endpoint string, from, to CircuitState)
+	now           func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(cfg CircuitBreakerConfig) *circuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultCircuitFailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultCircuitOpenTimeout
+	}
+	return &circuitBreaker{
+		threshold:     cfg.FailureThreshold,
+		openTimeout:   cfg.OpenTimeout,
+		onStateChange: cfg.OnStateChange,
+		now:           time.Now,
+		circuits:      make(map[string]*circuit),
+	}
+}
+
+// allow returns CircuitOpenError if request to path must not be sent.
+func (b *circuitBreaker) allow(path string) error {
+	endpoint := normalizeRecorderPath(path)
+
+	b.mu.Lock()
+	cb := b.circuit(endpoint)
+	var transition func()
+	switch cb.state {
+	case CircuitOpen:
+		retryAt := cb.openedAt.Add(b.openTimeout)
+		if b.now().Before(retryAt) {
+			b.mu.Unlock()
+			return &CircuitOpenError{Endpoint: endpoint, RetryAt: retryAt}
+		}
+		transition = b.setState(endpoint, cb, CircuitHalfOpen)
+		cb.probing = true
+	case CircuitHalfOpen:
+		if cb.probing {
+			b.mu.Unlock()
+			return &CircuitOpenError{Endpoint: endpoint, RetryAt: b.now()}
+		}
+		cb.probing = true
+	}
+	b.mu.Unlock()
+
+	if transition != nil {
+		transition()
+	}
+	return nil
+}
+
+// record reports result of a request allowed by allow.
+func (b *circuitBreaker) record(path string, outcome circuitOutcome) {
+	endpoint := normalizeRecorderPath(path)
+
+	b.mu.Lock()
+	cb := b.circuit(endpoint)
+	var transition func()
+	switch outcome {
+	case circuitSuccess:
+		cb.failures = 0
+		cb.probing = false
+		transition = b.setState(endpoint, cb, CircuitClosed)
+	case circuitFailure:
+		cb.failures++
+		if cb.state == CircuitHalfOpen || cb.failures >= b.threshold {
+			cb.openedAt = b.now()
+			cb.probing = false
+			transition = b.setState(endpoint, cb, CircuitOpen)
+		}
+	case circuitIgnored:
+		cb.probing = false
+	}
+	b.mu.Unlock()
+
+	if transition != nil {
+		transition()
+	}
+}
+
+func (b *circuitBreaker) circuit(endpoint string) *circuit {
+	cb, ok := b.circuits[endpoint]
+	if !ok {
+		cb = &circuit{}
+		b.circuits[endpoint] = cb
+	}
+	return cb
+}
+
+// setState switches state under lock and returns notification to run after unlock.
+func (b *circuitBreaker) setState(endpoint string, cb *circuit, to CircuitState) func() {
+	from := cb.state
+	if from == to {
+		return nil
+	}
+	cb.state = to
+	return func() {
+		logger.Warn("Circuit breaker: path=%s %s -> %s", endpoint, from, to)
+		if b.onStateChange != nil {
+			b.onStateChange(endpoint, from, to)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package go_monobank
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stremovskyy/go-monobank/consts"
+)
+
+func TestCircuitBreakerStateMachine(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var transitions []string
+	now := time.Unix(0, 0)
+
+	b := newCircuitBreaker(
+		CircuitBreakerConfig{
+			FailureThreshold: 2,
+			OpenTimeout:      10 * time.Second,
+			OnStateChange: func(endpoint string, from, to CircuitState) {
+				mu.Lock()
+				defer mu.Unlock()
+				transitions = append(transitions, endpoint+":"+from.String()+"->"+to.String())
+			},
+		},
+	)
+	b.now = func() time.Time { return now }
+
+	path := consts.PathInvoiceStatus + "?invoiceId=inv-1"
+	for i := 0; i < 2; i++ {
+		if err := b.allow(path); err != nil {
+			t.Fatalf("allow() before threshold: %v", err)
+		}
+		b.record(path, circuitFailure)
+	}
+
+	err := b.allow(path)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if err := b.allow(consts.PathWallet); err != nil {
+		t.Fatalf("other endpoint must stay closed: %v", err)
+	}
+
+	now = now.Add(11 * time.Second)
+	if err := b.allow(path); err != nil {
+		t.Fatalf("probe must be allowed after OpenTimeout: %v", err)
+	}
+	if err := b.allow(path); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("only one probe must be allowed in half-open, got %v", err)
+	}
+	b.record(path, circuitSuccess)
+	if err := b.allow(path); err != nil {
+		t.Fatalf("closed circuit must allow requests: %v", err)
+	}
+
+	want := []string{
+		consts.PathInvoiceStatus + ":closed->open",
+		consts.PathInvoiceStatus + ":open->half-open",
+		consts.PathInvoiceStatus + ":half-open->closed",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("transitions = %v, want %v", transitions, want)
+		}
+	}
+}
+
+func TestCircuitBreakerOpensOnServerErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if r.URL.Path == consts.PathInvoiceStatus {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithToken("merchant-token"),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute}),
+	)
+
+	request := NewRequest().WithInvoiceID("inv-1")
+	for i := 0; i < 2; i++ {
+		if _, err := client.Status(request); !errors.Is(err, ErrServerError) {
+			t.Fatalf("expected ErrServerError, got %v", err)
+		}
+	}
+	if _, err := client.Status(request); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("calls = %d, want 2", got)
+	}
+
+	// 4xx responses do not trip the breaker.
+	for i := 0; i < 3; i++ {
+		if _, err := client.Wallet(NewRequest().WithWalletID("wallet-1")); !errors.Is(err, ErrBadRequest) {
+			t.Fatalf("expected ErrBadRequest, got %v", err)
+		}
+	}
+}
@@ -28,6 +28,17 @@ type client struct {
 
 	pubKeyMu sync.Mutex
 	pubKey   *ecdsa.PublicKey
+	// pubKeyBase64 is the last known key (base64 PEM) used to report rotations.
+	pubKeyBase64    string
+	prevPubKey      *ecdsa.PublicKey
+	prevPubKeyUntil time.Time
+	// pubKeyStale is set by InvalidateWebhookPublicKey: next lookup fetches key from API.
+	pubKeyStale     bool
+	pubKeyFetchedAt time.Time
+	// pubKeyFetchErr is the error of the last failed fetch, returned until the refetch interval passes.
+	pubKeyFetchErr error
+	// pubKeyFetchMu serializes key fetches so concurrent verifications share one request.
+	pubKeyFetchMu sync.Mutex
 }
 
 var _ Monobank = (*client)(nil)
@@ -41,13 +52,45 @@ func (c *client) SetLogLevel(level log.Level) {
 // Verification creates an invoice with saveCardData (tokenization).
 // Under the hood: POST /api/merchant/invoice/create.
 func (c *client) Verification(request *Request, runOpts ...RunOption) (*InvoiceCreateResponse, error) {
+	return c.VerificationContext(context.Background(), request, runOpts...)
+}
+
+// VerificationContext is like Verification but honors ctx cancellation and deadlines.
+func (c *client) VerificationContext(ctx context.Context, request *Request, runOpts ...RunOption) (*InvoiceCreateResponse, error) {
+	return c.invoiceCreate(ctx, "verification", "verification", validateVerificationInvoice, request, runOpts...)
+}
+
+// CreateInvoice creates a regular hosted-checkout invoice.
+// Unlike Verification it is not tokenization-centric: paymentType must be debit (default)
+// or hold with amount > 0, saveCardData is optional and qrId, code (sub-merchant),
+// agentFeePercent, displayType and tipsEmployeeId are supported.
+// Under the hood: POST /api/merchant/invoice/create.
+func (c *client) CreateInvoice(request *Request, runOpts ...RunOption) (*InvoiceCreateResponse, error) {
+	return c.CreateInvoiceContext(context.Background(), request, runOpts...)
+}
+
+// CreateInvoiceContext is like CreateInvoice but honors ctx cancellation and deadlines.
+func (c *client) CreateInvoiceContext(ctx context.Context, request *Request, runOpts ...RunOption) (*InvoiceCreateResponse, error) {
+	return c.invoiceCreate(ctx, "createInvoice", "create_invoice", validateRegularInvoice, request, runOpts...)
+}
+
+// invoiceCreate serves Verification and CreateInvoice (same endpoint). validate holds
+// flow-specific amount/paymentType rules; recordOp is the recorder "operation" tag,
+// since it cannot be derived from the path.
+func (c *client) invoiceCreate(
+	ctx context.Context,
+	op, recordOp string,
+	validate func(op string, amount int64, paymentType PaymentType, request *Request) error,
+	request *Request,
+	runOpts ...RunOption,
+) (*InvoiceCreateResponse, error) {
 	if request == nil {
-		return nil, &ValidationError{Op: "verification", Msg: "request is nil"}
+		return nil, &ValidationError{Op: op, Msg: "request is nil"}
 	}
 
 	token := c.resolveToken(request)
 	if token == "" {
-		return nil, &ValidationError{Op: "verification", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+		return nil, &ValidationError{Op: op, Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
 	}
 
 	ccy := request.GetCurrency()
@@ -61,231 +104,938 @@ func (c *client) Verification(request *Request, runOpts ...RunOption) (*InvoiceC
 		paymentType = PaymentTypeDebit
 	}
 
+	if err := validate(op, amount, paymentType, request); err != nil {
+		return nil, err
+	}
+
+	if request.ShouldSaveCard() {
+		walletID := request.GetWalletID()
+		if strings.TrimSpace(walletID) == "" {
+			return nil, &ValidationError{Op: op, Msg: "walletId is required when SaveCard is enabled"}
+		}
+	}
+
+	if request.GetQrIDValue() != "" && request.ShouldSaveCard() {
+		return nil, &ValidationError{Op: op, Msg: "saveCardData is not supported for qrId invoices"}
+	}
+
+	if percent := request.GetAgentFeePercent(); percent != nil && (*percent < 0 || *percent > 100) {
+		return nil, &ValidationError{Op: op, Msg: "agentFeePercent must be between 0 and 100"}
+	}
+
+	if displayType := request.GetDisplayType(); displayType != "" && displayType != DisplayTypeIframe {
+		return nil, &ValidationError{Op: op, Msg: "displayType must be empty or iframe"}
+	}
+
+	if err := request.ValidateBasket(); err != nil {
+		return nil, &ValidationError{Op: op, Msg: err.Error(), Cause: err}
+	}
+
+	if err := request.validateSplits(nil); err != nil {
+		return nil, &ValidationError{Op: op, Msg: err.Error(), Cause: err}
+	}
+
+	payload := mapToInvoiceCreatePayload(request, amount, ccy)
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathInvoiceCreate
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, payload)
+		return nil, nil
+	}
+
+	var resp InvoiceCreateResponse
+	if err := c.doJSON(withRecorderOperation(ctx, recordOp), http.MethodPost, consts.PathInvoiceCreate, token, request, payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// validateVerificationInvoice keeps Verification rules: paymentType=verification is a
+// zero-amount tokenization with saveCardData; debit/hold require a positive amount.
+func validateVerificationInvoice(op string, amount int64, paymentType PaymentType, request *Request) error {
 	if paymentType == PaymentTypeVerification {
 		if amount != 0 {
-			return nil, &ValidationError{Op: "verification", Msg: "amount (minor units) must be 0 when paymentType=verification"}
+			return &ValidationError{Op: op, Msg: "amount (minor units) must be 0 when paymentType=verification"}
 		}
 		if !request.ShouldSaveCard() {
-			return nil, &ValidationError{Op: "verification", Msg: "saveCardData.saveCard is required when paymentType=verification"}
+			return &ValidationError{Op: op, Msg: "saveCardData.saveCard is required when paymentType=verification"}
 		}
-	} else if amount <= 0 {
-		return nil, &ValidationError{Op: "verification", Msg: "amount (minor units) must be > 0"}
+		return nil
+	}
+	if amount <= 0 {
+		return &ValidationError{Op: op, Msg: "amount (minor units) must be > 0"}
 	}
+	return nil
+}
 
-	if request.ShouldSaveCard() {
-		walletID := request.GetWalletID()
-		if strings.TrimSpace(walletID) == "" {
-			return nil, &ValidationError{Op: "verification", Msg: "walletId is required when SaveCard is enabled"}
+// validateRegularInvoice accepts only debit/hold invoices with a positive amount;
+// card verification goes through Verification.
+func validateRegularInvoice(op string, amount int64, paymentType PaymentType, _ *Request) error {
+	if paymentType != PaymentTypeDebit && paymentType != PaymentTypeHold {
+		return &ValidationError{Op: op, Msg: "paymentType must be debit or hold (use Verification for paymentType=verification)"}
+	}
+	if amount <= 0 {
+		return &ValidationError{Op: op, Msg: "amount (minor units) must be > 0"}
+	}
+	return nil
+}
+
+func (c *client) VerificationLink(request *Request, runOpts ...RunOption) (*url.URL, error) {
+	return c.VerificationLinkContext(context.Background(), request, runOpts...)
+}
+
+// VerificationLinkContext is like VerificationLink but honors ctx cancellation and deadlines.
+func (c *client) VerificationLinkContext(ctx context.Context, request *Request, runOpts ...RunOption) (*url.URL, error) {
+	resp, err := c.VerificationContext(ctx, request, runOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	parsed, err := url.Parse(strings.TrimSpace(resp.PageURL))
+	if err != nil {
+		return nil, fmt.Errorf("verification: cannot parse pageUrl %q: %w", resp.PageURL, err)
+	}
+	if !parsed.IsAbs() {
+		return nil, fmt.Errorf("verification: pageUrl is not absolute: %q", resp.PageURL)
+	}
+	return parsed, nil
+}
+
+// Payment performs a charge by tokenized card or direct wallet token.
+// Under the hood: POST /api/merchant/wallet/payment.
+func (c *client) Payment(request *Request, runOpts ...RunOption) (*WalletPaymentResponse, error) {
+	return c.PaymentContext(context.Background(), request, runOpts...)
+}
+
+// PaymentContext is like Payment but honors ctx cancellation and deadlines.
+func (c *client) PaymentContext(ctx context.Context, request *Request, runOpts ...RunOption) (*WalletPaymentResponse, error) {
+	return c.walletPayment(ctx, "payment", request, "", runOpts...)
+}
+
+// Hold performs a hold by tokenized card or direct wallet token.
+// Under the hood: POST /api/merchant/wallet/payment with paymentType=hold.
+func (c *client) Hold(request *Request, runOpts ...RunOption) (*WalletPaymentResponse, error) {
+	return c.HoldContext(context.Background(), request, runOpts...)
+}
+
+// HoldContext is like Hold but honors ctx cancellation and deadlines.
+func (c *client) HoldContext(ctx context.Context, request *Request, runOpts ...RunOption) (*WalletPaymentResponse, error) {
+	return c.walletPayment(ctx, "hold", request, PaymentTypeHold, runOpts...)
+}
+
+// PaymentDirect charges raw card data (PAN, expiry, CVV) collected by a PCI DSS-certified merchant.
+// Card data is never written to SDK logs, recorder or default dry-run output.
+// Under the hood: POST /api/merchant/invoice/payment-direct.
+func (c *client) PaymentDirect(request *Request, runOpts ...RunOption) (*WalletPaymentResponse, error) {
+	return c.PaymentDirectContext(context.Background(), request, runOpts...)
+}
+
+// PaymentDirectContext is like PaymentDirect but honors ctx cancellation and deadlines.
+func (c *client) PaymentDirectContext(ctx context.Context, request *Request, runOpts ...RunOption) (*WalletPaymentResponse, error) {
+	if request == nil {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: "request is nil"}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	card := request.GetCardData()
+	if card == nil {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: "cardData is required (set request.WithCardData(...))"}
+	}
+	if err := card.Validate(); err != nil {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: err.Error()}
+	}
+	if request.GetCardToken() != "" {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: "only one payment source is allowed (cardData or cardToken)"}
+	}
+	if _, err := request.GetAToken(); !errors.Is(err, errATokenNotSet) {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: "only one payment source is allowed (cardData or Apple/Google Pay aToken)"}
+	}
+
+	amount := request.GetAmount()
+	if amount <= 0 {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: "amount (minor units) must be > 0"}
+	}
+
+	ccy := request.GetCurrency()
+	if ccy == 0 {
+		ccy = CurrencyUAH
+	}
+
+	paymentType := request.GetPaymentType()
+	if paymentType == "" {
+		paymentType = PaymentTypeDebit
+	}
+	if paymentType != PaymentTypeDebit && paymentType != PaymentTypeHold {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: "paymentType must be debit or hold"}
+	}
+
+	if request.ShouldSaveCard() && request.GetWalletID() == "" {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: "walletId is required when SaveCard is enabled"}
+	}
+
+	if err := request.ValidateBasket(); err != nil {
+		return nil, &ValidationError{Op: "paymentDirect", Msg: err.Error(), Cause: err}
+	}
+
+	payload := mapToPaymentDirectPayload(request, *card, amount, ccy, paymentType)
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathInvoicePaymentDirect
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, payload.redacted())
+		return nil, nil
+	}
+
+	var resp WalletPaymentResponse
+	if err := c.doJSON(ctx, http.MethodPost, consts.PathInvoicePaymentDirect, token, request, payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *client) walletPayment(
+	ctx context.Context,
+	op string,
+	request *Request,
+	forcedPaymentType PaymentType,
+	runOpts ...RunOption,
+) (*WalletPaymentResponse, error) {
+	if request == nil {
+		return nil, &ValidationError{Op: op, Msg: "request is nil"}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: op, Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	source, err := resolveWalletPaymentSource(request)
+	if err != nil {
+		return nil, &ValidationError{Op: op, Msg: err.Error()}
+	}
+
+	amount := request.GetAmount()
+	if amount <= 0 {
+		return nil, &ValidationError{Op: op, Msg: "amount (minor units) must be > 0"}
+	}
+
+	ccy := request.GetCurrency()
+	if ccy == 0 {
+		ccy = CurrencyUAH
+	}
+
+	initKind := request.GetInitiationKind()
+	if strings.TrimSpace(string(initKind)) == "" {
+		return nil, &ValidationError{Op: op, Msg: "initiationKind is required (merchant|client)"}
+	}
+
+	paymentType := request.GetPaymentType()
+	if forcedPaymentType != "" {
+		paymentType = forcedPaymentType
+	}
+	if paymentType == "" {
+		paymentType = PaymentTypeDebit
+	}
+	if paymentType == PaymentTypeVerification {
+		return nil, &ValidationError{Op: op, Msg: "paymentType=verification is only supported by Verification"}
+	}
+	if paymentType != PaymentTypeDebit && paymentType != PaymentTypeHold {
+		return nil, &ValidationError{Op: op, Msg: "paymentType must be debit or hold"}
+	}
+
+	if err := request.ValidateBasket(); err != nil {
+		return nil, &ValidationError{Op: op, Msg: err.Error(), Cause: err}
+	}
+
+	if err := request.validateSplits(nil); err != nil {
+		return nil, &ValidationError{Op: op, Msg: err.Error(), Cause: err}
+	}
+
+	payload := mapToWalletPaymentPayload(request, source, amount, ccy, initKind, paymentType)
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathWalletPayment
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, payload)
+		return nil, nil
+	}
+
+	var resp WalletPaymentResponse
+	if err := c.doJSON(ctx, http.MethodPost, consts.PathWalletPayment, token, request, payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Status returns invoice status.
+// Under the hood: GET /api/merchant/invoice/status?invoiceId=...
+func (c *client) Status(request *Request, runOpts ...RunOption) (*InvoiceStatusResponse, error) {
+	return c.StatusContext(context.Background(), request, runOpts...)
+}
+
+// StatusContext is like Status but honors ctx cancellation and deadlines.
+func (c *client) StatusContext(ctx context.Context, request *Request, runOpts ...RunOption) (*InvoiceStatusResponse, error) {
+	if request == nil {
+		return nil, &ValidationError{Op: "status", Msg: "request is nil"}
+	}
+	// Token
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "status", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	invoiceID := request.GetInvoiceID()
+	if invoiceID == "" {
+		return nil, &ValidationError{Op: "status", Msg: "invoiceId is required (set request.WithInvoiceID(...))"}
+	}
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathInvoiceStatus + "?invoiceId=" + url.QueryEscape(invoiceID)
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, map[string]string{"invoiceId": invoiceID})
+		return nil, nil
+	}
+
+	var resp InvoiceStatusResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathInvoiceStatus+"?invoiceId="+url.QueryEscape(invoiceID), token, request, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Wallet lists tokenized cards by walletId.
+// Under the hood: GET /api/merchant/wallet?walletId=...
+func (c *client) Wallet(request *Request, runOpts ...RunOption) (*WalletResponse, error) {
+	return c.WalletContext(context.Background(), request, runOpts...)
+}
+
+// WalletContext is like Wallet but honors ctx cancellation and deadlines.
+func (c *client) WalletContext(ctx context.Context, request *Request, runOpts ...RunOption) (*WalletResponse, error) {
+	if request == nil {
+		return nil, &ValidationError{Op: "wallet", Msg: "request is nil"}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "wallet", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	walletID := request.GetWalletID()
+	if walletID == "" {
+		return nil, &ValidationError{Op: "wallet", Msg: "walletId is required (set request.WithWalletID(...))"}
+	}
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathWallet + "?walletId=" + url.QueryEscape(walletID)
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, map[string]string{"walletId": walletID})
+		return nil, nil
+	}
+
+	var resp WalletResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathWallet+"?walletId="+url.QueryEscape(walletID), token, request, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DeleteWalletCard removes a tokenized card by cardToken.
+// Under the hood: DELETE /api/merchant/wallet/card?cardToken=...
+func (c *client) DeleteWalletCard(request *Request, runOpts ...RunOption) error {
+	return c.DeleteWalletCardContext(context.Background(), request, runOpts...)
+}
+
+// DeleteWalletCardContext is like DeleteWalletCard but honors ctx cancellation and deadlines.
+func (c *client) DeleteWalletCardContext(ctx context.Context, request *Request, runOpts ...RunOption) error {
+	if request == nil {
+		return &ValidationError{Op: "deleteWalletCard", Msg: "request is nil"}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return &ValidationError{Op: "deleteWalletCard", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	cardToken := request.GetCardToken()
+	if cardToken == "" {
+		return &ValidationError{Op: "deleteWalletCard", Msg: "cardToken is required (set request.WithCardToken(...))"}
+	}
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathWalletCard + "?cardToken=" + url.QueryEscape(cardToken)
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, map[string]string{"cardToken": cardToken})
+		return nil
+	}
+
+	return c.doJSON(ctx, http.MethodDelete, consts.PathWalletCard+"?cardToken="+url.QueryEscape(cardToken), token, request, nil, nil)
+}
+
+// ClearWallet removes all tokenized cards of walletId: lists them via Wallet
+// and deletes each one via DeleteWalletCard.
+// It returns cards that were deleted before the first error (if any).
+func (c *client) ClearWallet(request *Request, runOpts ...RunOption) ([]WalletItem, error) {
+	return c.ClearWalletContext(context.Background(), request, runOpts...)
+}
+
+// ClearWalletContext is like ClearWallet but honors ctx cancellation and deadlines.
+func (c *client) ClearWalletContext(ctx context.Context, request *Request, runOpts ...RunOption) ([]WalletItem, error) {
+	wallet, err := c.WalletContext(ctx, request, runOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		// dry run
+		return nil, nil
+	}
+
+	deleted := make([]WalletItem, 0, len(wallet.Wallet))
+	for _, card := range wallet.Wallet {
+		cardRequest := NewRequest().WithCardToken(card.CardToken)
+		cardRequest.Merchant = request.Merchant
+		if err := c.DeleteWalletCardContext(ctx, cardRequest, runOpts...); err != nil {
+			return deleted, err
 		}
+		deleted = append(deleted, card)
 	}
+	return deleted, nil
+}
 
-	payload := mapToInvoiceCreatePayload(request, amount, ccy)
+// FiscalChecks returns PRRO fiscal checks for invoice.
+// Under the hood: GET /api/merchant/invoice/fiscal-checks?invoiceId=...
+func (c *client) FiscalChecks(request *Request, runOpts ...RunOption) (*FiscalChecksResponse, error) {
+	return c.FiscalChecksContext(context.Background(), request, runOpts...)
+}
+
+// FiscalChecksContext is like FiscalChecks but honors ctx cancellation and deadlines.
+func (c *client) FiscalChecksContext(ctx context.Context, request *Request, runOpts ...RunOption) (*FiscalChecksResponse, error) {
+	if request == nil {
+		return nil, &ValidationError{Op: "fiscalChecks", Msg: "request is nil"}
+	}
+	// Token
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "fiscalChecks", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	invoiceID := request.GetInvoiceID()
+	if invoiceID == "" {
+		return nil, &ValidationError{Op: "fiscalChecks", Msg: "invoiceId is required (set request.WithInvoiceID(...))"}
+	}
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathInvoiceFiscalChecks + "?invoiceId=" + url.QueryEscape(invoiceID)
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, map[string]string{"invoiceId": invoiceID})
+		return nil, nil
+	}
+
+	var resp FiscalChecksResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathInvoiceFiscalChecks+"?invoiceId="+url.QueryEscape(invoiceID), token, request, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PaymentInfo returns card/terminal details of a paid invoice.
+// Under the hood: GET /api/merchant/invoice/payment-info?invoiceId=...
+func (c *client) PaymentInfo(request *Request, runOpts ...RunOption) (*PaymentInfo, error) {
+	return c.PaymentInfoContext(context.Background(), request, runOpts...)
+}
+
+// PaymentInfoContext is like PaymentInfo but honors ctx cancellation and deadlines.
+func (c *client) PaymentInfoContext(ctx context.Context, request *Request, runOpts ...RunOption) (*PaymentInfo, error) {
+	if request == nil {
+		return nil, &ValidationError{Op: "paymentInfo", Msg: "request is nil"}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "paymentInfo", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	invoiceID := request.GetInvoiceID()
+	if invoiceID == "" {
+		return nil, &ValidationError{Op: "paymentInfo", Msg: "invoiceId is required (set request.WithInvoiceID(...))"}
+	}
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathInvoicePaymentInfo + "?invoiceId=" + url.QueryEscape(invoiceID)
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, map[string]string{"invoiceId": invoiceID})
+		return nil, nil
+	}
+
+	var resp PaymentInfo
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathInvoicePaymentInfo+"?invoiceId="+url.QueryEscape(invoiceID), token, request, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Cancel performs a full or partial refund (or hold cancellation) of a paid invoice.
+// Full cancellation is performed when amount is 0.
+// Under the hood: POST /api/merchant/invoice/cancel.
+func (c *client) Cancel(request *Request, runOpts ...RunOption) (*CancelResponse, error) {
+	return c.CancelContext(context.Background(), request, runOpts...)
+}
+
+// CancelContext is like Cancel but honors ctx cancellation and deadlines.
+func (c *client) CancelContext(ctx context.Context, request *Request, runOpts ...RunOption) (*CancelResponse, error) {
+	return c.invoiceCancel(ctx, "cancel", request, runOpts...)
+}
+
+// ReleaseHold cancels a hold created by Hold and releases the whole held amount.
+// Under the hood: POST /api/merchant/invoice/cancel without amount.
+func (c *client) ReleaseHold(request *Request, runOpts ...RunOption) (*CancelResponse, error) {
+	return c.ReleaseHoldContext(context.Background(), request, runOpts...)
+}
+
+// ReleaseHoldContext is like ReleaseHold but honors ctx cancellation and deadlines.
+func (c *client) ReleaseHoldContext(ctx context.Context, request *Request, runOpts ...RunOption) (*CancelResponse, error) {
+	if request != nil && request.GetAmount() != 0 {
+		return nil, &ValidationError{Op: "releaseHold", Msg: "amount must be empty: hold is released in full (use Finalize with smaller amount to capture part of it)"}
+	}
+	return c.invoiceCancel(ctx, "releaseHold", request, runOpts...)
+}
+
+func (c *client) invoiceCancel(ctx context.Context, op string, request *Request, runOpts ...RunOption) (*CancelResponse, error) {
+	if request == nil {
+		return nil, &ValidationError{Op: op, Msg: "request is nil"}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: op, Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	invoiceID := request.GetInvoiceID()
+	if invoiceID == "" {
+		return nil, &ValidationError{Op: op, Msg: "invoiceId is required (set request.WithInvoiceID(...))"}
+	}
+
+	amount := request.GetAmount()
+	if amount < 0 {
+		return nil, &ValidationError{Op: op, Msg: "amount (minor units) must be >= 0 (0 means full cancellation)"}
+	}
+
+	if err := validateBasketItems(request.GetItems()); err != nil {
+		return nil, &ValidationError{Op: op, Msg: err.Error()}
+	}
+
+	payload := mapToInvoiceCancelPayload(request, invoiceID, amount)
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathInvoiceCancel
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, payload)
+		return nil, nil
+	}
+
+	var resp CancelResponse
+	if err := c.doJSON(ctx, http.MethodPost, consts.PathInvoiceCancel, token, request, payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveInvoice invalidates an unpaid invoice so its pageUrl is no longer payable.
+// If invoice is already final, the returned error matches ErrInvoiceAlreadyFinal.
+// Under the hood: POST /api/merchant/invoice/remove.
+func (c *client) RemoveInvoice(request *Request, runOpts ...RunOption) error {
+	return c.RemoveInvoiceContext(context.Background(), request, runOpts...)
+}
+
+// RemoveInvoiceContext is like RemoveInvoice but honors ctx cancellation and deadlines.
+func (c *client) RemoveInvoiceContext(ctx context.Context, request *Request, runOpts ...RunOption) error {
+	if request == nil {
+		return &ValidationError{Op: "removeInvoice", Msg: "request is nil"}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return &ValidationError{Op: "removeInvoice", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	invoiceID := request.GetInvoiceID()
+	if invoiceID == "" {
+		return &ValidationError{Op: "removeInvoice", Msg: "invoiceId is required (set request.WithInvoiceID(...))"}
+	}
+
+	payload := map[string]string{"invoiceId": invoiceID}
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathInvoiceRemove
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, payload)
+		return nil
+	}
+
+	err := c.doJSON(ctx, http.MethodPost, consts.PathInvoiceRemove, token, request, payload, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && c.isInvoiceAlreadyFinal(ctx, request, apiErr) {
+		logger.Info("Remove invoice: invoice_id=%s is already final", invoiceID)
+		return &InvoiceAlreadyFinalError{InvoiceID: invoiceID, Cause: apiErr}
+	}
+	return err
+}
+
+// isInvoiceAlreadyFinal reports whether invoice/remove was rejected because invoice is
+// not in a removable (created/processing) state.
+//
+// Monobank docs define no dedicated errCode for this case: invoice/remove answers with the
+// generic 400 BAD_REQUEST, and errText wording is not a stable contract (it may change or
+// be localized). So the 400 is confirmed with invoice/status instead of being parsed.
+// If the status call fails, the original error is kept.
+func (c *client) isInvoiceAlreadyFinal(ctx context.Context, request *Request, apiErr *APIError) bool {
+	if apiErr == nil || apiErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	status, err := c.StatusContext(ctx, request)
+	if err != nil {
+		logger.Debug("Remove invoice: status check failed: %v", err)
+		return false
+	}
+	return status.Status.IsFinal()
+}
+
+// Finalize captures a hold created by Hold, fully or partially.
+// Under the hood: POST /api/merchant/invoice/finalize.
+func (c *client) Finalize(request *Request, runOpts ...RunOption) (*FinalizeResponse, error) {
+	return c.FinalizeContext(context.Background(), request, runOpts...)
+}
+
+// FinalizeContext is like Finalize but honors ctx cancellation and deadlines.
+func (c *client) FinalizeContext(ctx context.Context, request *Request, runOpts ...RunOption) (*FinalizeResponse, error) {
+	if request == nil {
+		return nil, &ValidationError{Op: "finalize", Msg: "request is nil"}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "finalize", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	invoiceID := request.GetInvoiceID()
+	if invoiceID == "" {
+		return nil, &ValidationError{Op: "finalize", Msg: "invoiceId is required (set request.WithInvoiceID(...) or request.ForHold(...))"}
+	}
+
+	amount := request.GetAmount()
+	if amount < 0 {
+		return nil, &ValidationError{Op: "finalize", Msg: "amount (minor units) must be >= 0 (0 means full hold amount)"}
+	}
+	if held := request.GetHeldAmount(); held > 0 && amount > held {
+		return nil, &ValidationError{Op: "finalize", Msg: fmt.Sprintf("amount %d exceeds held amount %d", amount, held)}
+	}
+
+	if err := validateBasketItems(request.GetItems()); err != nil {
+		return nil, &ValidationError{Op: "finalize", Msg: err.Error()}
+	}
+
+	payload := mapToInvoiceFinalizePayload(request, invoiceID, amount)
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathInvoiceFinalize
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, payload)
+		return nil, nil
+	}
+
+	var resp FinalizeResponse
+	if err := c.doJSON(ctx, http.MethodPost, consts.PathInvoiceFinalize, token, request, payload, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Statement returns settled operations for a period of at most StatementMaxWindow.
+// Use StatementAll to iterate over longer periods.
+// Under the hood: GET /api/merchant/statement?from=...&to=...&code=...
+func (c *client) Statement(request *Request, runOpts ...RunOption) (*StatementResponse, error) {
+	return c.StatementContext(context.Background(), request, runOpts...)
+}
+
+// StatementContext is like Statement but honors ctx cancellation and deadlines.
+func (c *client) StatementContext(ctx context.Context, request *Request, runOpts ...RunOption) (*StatementResponse, error) {
+	if request == nil {
+		return nil, &ValidationError{Op: "statement", Msg: "request is nil"}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "statement", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
+
+	from, to, err := resolveStatementPeriod(request)
+	if err != nil {
+		return nil, &ValidationError{Op: "statement", Msg: err.Error()}
+	}
+	if to.Sub(from) > StatementMaxWindow {
+		return nil, &ValidationError{Op: "statement", Msg: fmt.Sprintf("period must not exceed %s (use StatementAll to split it)", StatementMaxWindow)}
+	}
+
+	query := url.Values{}
+	query.Set("from", strconv.FormatInt(from.Unix(), 10))
+	query.Set("to", strconv.FormatInt(to.Unix(), 10))
+	if code := request.GetCode(); code != nil && strings.TrimSpace(*code) != "" {
+		query.Set("code", strings.TrimSpace(*code))
+	}
+
+	opts := collectRunOptions(runOpts)
+	path := consts.PathStatement + "?" + query.Encode()
+	if opts.isDryRun() {
+		c.handleDryRun(opts, c.cfg.baseURL+path, query)
+		return nil, nil
+	}
+
+	var resp StatementResponse
+	if err := c.doJSON(ctx, http.MethodGet, path, token, request, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// MerchantDetails returns merchant id, name and EDRPOU of the token owner.
+// Under the hood: GET /api/merchant/details.
+func (c *client) MerchantDetails(request *Request, runOpts ...RunOption) (*MerchantDetailsResponse, error) {
+	return c.MerchantDetailsContext(context.Background(), request, runOpts...)
+}
+
+// MerchantDetailsContext is like MerchantDetails but honors ctx cancellation and deadlines.
+func (c *client) MerchantDetailsContext(ctx context.Context, request *Request, runOpts ...RunOption) (*MerchantDetailsResponse, error) {
+	if request == nil {
+		request = &Request{}
+	}
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "merchantDetails", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+	}
 
 	opts := collectRunOptions(runOpts)
-	endpoint := c.cfg.baseURL + consts.PathInvoiceCreate
+	endpoint := c.cfg.baseURL + consts.PathMerchantDetails
 	if opts.isDryRun() {
-		opts.handleDryRun(endpoint, payload)
+		c.handleDryRun(opts, endpoint, nil)
 		return nil, nil
 	}
 
-	var resp InvoiceCreateResponse
-	if err := c.doJSON(context.Background(), http.MethodPost, consts.PathInvoiceCreate, token, request, payload, &resp); err != nil {
+	var resp MerchantDetailsResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathMerchantDetails, token, request, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
-func (c *client) VerificationLink(request *Request, runOpts ...RunOption) (*url.URL, error) {
-	resp, err := c.Verification(request, runOpts...)
-	if err != nil {
-		return nil, err
-	}
-	if resp == nil {
-		return nil, nil
+// SubMerchants lists sub-merchants (shops) available under the token.
+// Under the hood: GET /api/merchant/submerchant/list.
+func (c *client) SubMerchants(request *Request, runOpts ...RunOption) (*SubMerchantListResponse, error) {
+	return c.SubMerchantsContext(context.Background(), request, runOpts...)
+}
+
+// SubMerchantsContext is like SubMerchants but honors ctx cancellation and deadlines.
+func (c *client) SubMerchantsContext(ctx context.Context, request *Request, runOpts ...RunOption) (*SubMerchantListResponse, error) {
+	if request == nil {
+		request = &Request{}
 	}
-	parsed, err := url.Parse(strings.TrimSpace(resp.PageURL))
-	if err != nil {
-		return nil, fmt.Errorf("verification: cannot parse pageUrl %q: %w", resp.PageURL, err)
+
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "subMerchants", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
 	}
-	if !parsed.IsAbs() {
-		return nil, fmt.Errorf("verification: pageUrl is not absolute: %q", resp.PageURL)
+
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathSubMerchantList
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, nil)
+		return nil, nil
 	}
-	return parsed, nil
-}
 
-// Payment performs a charge by tokenized card or direct wallet token.
-// Under the hood: POST /api/merchant/wallet/payment.
-func (c *client) Payment(request *Request, runOpts ...RunOption) (*WalletPaymentResponse, error) {
-	return c.walletPayment("payment", request, "", runOpts...)
+	var resp SubMerchantListResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathSubMerchantList, token, request, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
-// Hold performs a hold by tokenized card or direct wallet token.
-// Under the hood: POST /api/merchant/wallet/payment with paymentType=hold.
-func (c *client) Hold(request *Request, runOpts ...RunOption) (*WalletPaymentResponse, error) {
-	return c.walletPayment("hold", request, PaymentTypeHold, runOpts...)
+// Employees lists merchant employees that can receive tips.
+// Under the hood: GET /api/merchant/employee/list.
+func (c *client) Employees(request *Request, runOpts ...RunOption) (*EmployeeListResponse, error) {
+	return c.EmployeesContext(context.Background(), request, runOpts...)
 }
 
-func (c *client) walletPayment(
-	op string,
-	request *Request,
-	forcedPaymentType PaymentType,
-	runOpts ...RunOption,
-) (*WalletPaymentResponse, error) {
+// EmployeesContext is like Employees but honors ctx cancellation and deadlines.
+func (c *client) EmployeesContext(ctx context.Context, request *Request, runOpts ...RunOption) (*EmployeeListResponse, error) {
 	if request == nil {
-		return nil, &ValidationError{Op: op, Msg: "request is nil"}
+		request = &Request{}
 	}
 
 	token := c.resolveToken(request)
 	if token == "" {
-		return nil, &ValidationError{Op: op, Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+		return nil, &ValidationError{Op: "employees", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
 	}
 
-	source, err := resolveWalletPaymentSource(request)
-	if err != nil {
-		return nil, &ValidationError{Op: op, Msg: err.Error()}
+	opts := collectRunOptions(runOpts)
+	endpoint := c.cfg.baseURL + consts.PathEmployeeList
+	if opts.isDryRun() {
+		c.handleDryRun(opts, endpoint, nil)
+		return nil, nil
 	}
 
-	amount := request.GetAmount()
-	if amount <= 0 {
-		return nil, &ValidationError{Op: op, Msg: "amount (minor units) must be > 0"}
+	var resp EmployeeListResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathEmployeeList, token, request, nil, &resp); err != nil {
+		return nil, err
 	}
+	return &resp, nil
+}
 
-	ccy := request.GetCurrency()
-	if ccy == 0 {
-		ccy = CurrencyUAH
-	}
+// SplitReceivers lists split payment receivers of a marketplace merchant.
+// Under the hood: GET /api/merchant/split-receiver/list.
+func (c *client) SplitReceivers(request *Request, runOpts ...RunOption) (*SplitReceiverListResponse, error) {
+	return c.SplitReceiversContext(context.Background(), request, runOpts...)
+}
 
-	initKind := request.GetInitiationKind()
-	if strings.TrimSpace(string(initKind)) == "" {
-		return nil, &ValidationError{Op: op, Msg: "initiationKind is required (merchant|client)"}
+// SplitReceiversContext is like SplitReceivers but honors ctx cancellation and deadlines.
+func (c *client) SplitReceiversContext(ctx context.Context, request *Request, runOpts ...RunOption) (*SplitReceiverListResponse, error) {
+	if request == nil {
+		request = &Request{}
 	}
 
-	paymentType := request.GetPaymentType()
-	if forcedPaymentType != "" {
-		paymentType = forcedPaymentType
-	}
-	if paymentType == "" {
-		paymentType = PaymentTypeDebit
-	}
-	if paymentType == PaymentTypeVerification {
-		return nil, &ValidationError{Op: op, Msg: "paymentType=verification is only supported by Verification"}
-	}
-	if paymentType != PaymentTypeDebit && paymentType != PaymentTypeHold {
-		return nil, &ValidationError{Op: op, Msg: "paymentType must be debit or hold"}
+	token := c.resolveToken(request)
+	if token == "" {
+		return nil, &ValidationError{Op: "splitReceivers", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
 	}
 
-	payload := mapToWalletPaymentPayload(request, source, amount, ccy, initKind, paymentType)
-
 	opts := collectRunOptions(runOpts)
-	endpoint := c.cfg.baseURL + consts.PathWalletPayment
+	endpoint := c.cfg.baseURL + consts.PathSplitReceiverList
 	if opts.isDryRun() {
-		opts.handleDryRun(endpoint, payload)
+		c.handleDryRun(opts, endpoint, nil)
 		return nil, nil
 	}
 
-	var resp WalletPaymentResponse
-	if err := c.doJSON(context.Background(), http.MethodPost, consts.PathWalletPayment, token, request, payload, &resp); err != nil {
+	var resp SplitReceiverListResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathSplitReceiverList, token, request, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
-// Status returns invoice status.
-// Under the hood: GET /api/merchant/invoice/status?invoiceId=...
-func (c *client) Status(request *Request, runOpts ...RunOption) (*InvoiceStatusResponse, error) {
+// QRList returns merchant QR cash registers.
+// Under the hood: GET /api/merchant/qr/list.
+func (c *client) QRList(request *Request, runOpts ...RunOption) (*QRListResponse, error) {
+	return c.QRListContext(context.Background(), request, runOpts...)
+}
+
+// QRListContext is like QRList but honors ctx cancellation and deadlines.
+func (c *client) QRListContext(ctx context.Context, request *Request, runOpts ...RunOption) (*QRListResponse, error) {
 	if request == nil {
-		return nil, &ValidationError{Op: "status", Msg: "request is nil"}
+		request = &Request{}
 	}
-	// Token
+
 	token := c.resolveToken(request)
 	if token == "" {
-		return nil, &ValidationError{Op: "status", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
-	}
-
-	invoiceID := request.GetInvoiceID()
-	if invoiceID == "" {
-		return nil, &ValidationError{Op: "status", Msg: "invoiceId is required (set request.WithInvoiceID(...))"}
+		return nil, &ValidationError{Op: "qrList", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
 	}
 
 	opts := collectRunOptions(runOpts)
-	endpoint := c.cfg.baseURL + consts.PathInvoiceStatus + "?invoiceId=" + url.QueryEscape(invoiceID)
+	endpoint := c.cfg.baseURL + consts.PathQRList
 	if opts.isDryRun() {
-		opts.handleDryRun(endpoint, map[string]string{"invoiceId": invoiceID})
+		c.handleDryRun(opts, endpoint, nil)
 		return nil, nil
 	}
 
-	var resp InvoiceStatusResponse
-	if err := c.doJSON(context.Background(), http.MethodGet, consts.PathInvoiceStatus+"?invoiceId="+url.QueryEscape(invoiceID), token, request, nil, &resp); err != nil {
+	var resp QRListResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathQRList, token, request, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
-// Wallet lists tokenized cards by walletId.
-// Under the hood: GET /api/merchant/wallet?walletId=...
-func (c *client) Wallet(request *Request, runOpts ...RunOption) (*WalletResponse, error) {
+// QRDetails returns QR cash register details, including pushed amount (if any).
+// Under the hood: GET /api/merchant/qr/details?qrId=...
+func (c *client) QRDetails(request *Request, runOpts ...RunOption) (*QRDetailsResponse, error) {
+	return c.QRDetailsContext(context.Background(), request, runOpts...)
+}
+
+// QRDetailsContext is like QRDetails but honors ctx cancellation and deadlines.
+func (c *client) QRDetailsContext(ctx context.Context, request *Request, runOpts ...RunOption) (*QRDetailsResponse, error) {
 	if request == nil {
-		return nil, &ValidationError{Op: "wallet", Msg: "request is nil"}
+		return nil, &ValidationError{Op: "qrDetails", Msg: "request is nil"}
 	}
 
 	token := c.resolveToken(request)
 	if token == "" {
-		return nil, &ValidationError{Op: "wallet", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+		return nil, &ValidationError{Op: "qrDetails", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
 	}
 
-	walletID := request.GetWalletID()
-	if walletID == "" {
-		return nil, &ValidationError{Op: "wallet", Msg: "walletId is required (set request.WithWalletID(...))"}
+	qrID := request.GetQrIDValue()
+	if qrID == "" {
+		return nil, &ValidationError{Op: "qrDetails", Msg: "qrId is required (set request.WithQrID(...))"}
 	}
 
 	opts := collectRunOptions(runOpts)
-	endpoint := c.cfg.baseURL + consts.PathWallet + "?walletId=" + url.QueryEscape(walletID)
+	endpoint := c.cfg.baseURL + consts.PathQRDetails + "?qrId=" + url.QueryEscape(qrID)
 	if opts.isDryRun() {
-		opts.handleDryRun(endpoint, map[string]string{"walletId": walletID})
+		c.handleDryRun(opts, endpoint, map[string]string{"qrId": qrID})
 		return nil, nil
 	}
 
-	var resp WalletResponse
-	if err := c.doJSON(context.Background(), http.MethodGet, consts.PathWallet+"?walletId="+url.QueryEscape(walletID), token, request, nil, &resp); err != nil {
+	var resp QRDetailsResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathQRDetails+"?qrId="+url.QueryEscape(qrID), token, request, nil, &resp); err != nil {
 		return nil, err
 	}
-
 	return &resp, nil
 }
 
-// FiscalChecks returns PRRO fiscal checks for invoice.
-// Under the hood: GET /api/merchant/invoice/fiscal-checks?invoiceId=...
-func (c *client) FiscalChecks(request *Request, runOpts ...RunOption) (*FiscalChecksResponse, error) {
+// QRResetAmount removes the amount pushed onto a QR cash register.
+// Under the hood: POST /api/merchant/qr/reset-amount.
+func (c *client) QRResetAmount(request *Request, runOpts ...RunOption) error {
+	return c.QRResetAmountContext(context.Background(), request, runOpts...)
+}
+
+// QRResetAmountContext is like QRResetAmount but honors ctx cancellation and deadlines.
+func (c *client) QRResetAmountContext(ctx context.Context, request *Request, runOpts ...RunOption) error {
 	if request == nil {
-		return nil, &ValidationError{Op: "fiscalChecks", Msg: "request is nil"}
+		return &ValidationError{Op: "qrResetAmount", Msg: "request is nil"}
 	}
-	// Token
+
 	token := c.resolveToken(request)
 	if token == "" {
-		return nil, &ValidationError{Op: "fiscalChecks", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
+		return &ValidationError{Op: "qrResetAmount", Msg: "X-Token is required (set request.WithToken(...) or client WithToken(...))"}
 	}
 
-	invoiceID := request.GetInvoiceID()
-	if invoiceID == "" {
-		return nil, &ValidationError{Op: "fiscalChecks", Msg: "invoiceId is required (set request.WithInvoiceID(...))"}
+	qrID := request.GetQrIDValue()
+	if qrID == "" {
+		return &ValidationError{Op: "qrResetAmount", Msg: "qrId is required (set request.WithQrID(...))"}
 	}
 
+	payload := map[string]string{"qrId": qrID}
+
 	opts := collectRunOptions(runOpts)
-	endpoint := c.cfg.baseURL + consts.PathInvoiceFiscalChecks + "?invoiceId=" + url.QueryEscape(invoiceID)
+	endpoint := c.cfg.baseURL + consts.PathQRResetAmount
 	if opts.isDryRun() {
-		opts.handleDryRun(endpoint, map[string]string{"invoiceId": invoiceID})
-		return nil, nil
+		c.handleDryRun(opts, endpoint, payload)
+		return nil
 	}
 
-	var resp FiscalChecksResponse
-	if err := c.doJSON(context.Background(), http.MethodGet, consts.PathInvoiceFiscalChecks+"?invoiceId="+url.QueryEscape(invoiceID), token, request, nil, &resp); err != nil {
-		return nil, err
-	}
-	return &resp, nil
+	return c.doJSON(ctx, http.MethodPost, consts.PathQRResetAmount, token, request, payload, nil)
 }
 
 // PublicKey fetches pubkey (base64-encoded PEM) used for webhook signature verification.
 func (c *client) PublicKey(request *Request, runOpts ...RunOption) (*PublicKeyResponse, error) {
+	return c.PublicKeyContext(context.Background(), request, runOpts...)
+}
+
+// PublicKeyContext is like PublicKey but honors ctx cancellation and deadlines.
+func (c *client) PublicKeyContext(ctx context.Context, request *Request, runOpts ...RunOption) (*PublicKeyResponse, error) {
 	if request == nil {
 		request = &Request{}
 	}
@@ -298,12 +1048,12 @@ func (c *client) PublicKey(request *Request, runOpts ...RunOption) (*PublicKeyRe
 	opts := collectRunOptions(runOpts)
 	endpoint := c.cfg.baseURL + consts.PathPubKey
 	if opts.isDryRun() {
-		opts.handleDryRun(endpoint, nil)
+		c.handleDryRun(opts, endpoint, nil)
 		return nil, nil
 	}
 
 	var resp PublicKeyResponse
-	if err := c.doJSON(context.Background(), http.MethodGet, consts.PathPubKey, token, request, nil, &resp); err != nil {
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathPubKey, token, request, nil, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -318,20 +1068,32 @@ func (c *client) ParseWebhook(body []byte) (*InvoiceStatusResponse, error) {
 	var event InvoiceStatusResponse
 	if err := json.Unmarshal(body, &event); err != nil {
 		logger.Error("Webhook parse: decode error: %v", err)
-		return nil, &DecodeError{Op: "webhook", Msg: "json unmarshal", Body: trimBody(body, 4096), Cause: err}
+		return nil, &DecodeError{Op: "webhook", Msg: "json unmarshal", Body: trimBody(c.redact(body), 4096), Cause: err}
 	}
 	logger.Info("Webhook parse: status=%s invoice_id=%s", event.Status, event.InvoiceID)
 	return &event, nil
 }
 
 func (c *client) ParseAndVerifyWebhook(body []byte, xSign string) (*InvoiceStatusResponse, error) {
-	if err := c.VerifyWebhook(body, xSign); err != nil {
+	return c.ParseAndVerifyWebhookContext(context.Background(), body, xSign)
+}
+
+// ParseAndVerifyWebhookContext is like ParseAndVerifyWebhook but uses ctx
+// when the public key has to be fetched from the API.
+func (c *client) ParseAndVerifyWebhookContext(ctx context.Context, body []byte, xSign string) (*InvoiceStatusResponse, error) {
+	if err := c.VerifyWebhookContext(ctx, body, xSign); err != nil {
 		return nil, err
 	}
 	return c.ParseWebhook(body)
 }
 
 func (c *client) VerifyWebhook(body []byte, xSign string) error {
+	return c.VerifyWebhookContext(context.Background(), body, xSign)
+}
+
+// VerifyWebhookContext is like VerifyWebhook but uses ctx
+// when the public key has to be fetched from the API.
+func (c *client) VerifyWebhookContext(ctx context.Context, body []byte, xSign string) error {
 	logger.Debug("Webhook verify: body_size=%d", len(body))
 	if len(body) == 0 {
 		logger.Error("Webhook verify: body is empty")
@@ -343,7 +1105,7 @@ func (c *client) VerifyWebhook(body []byte, xSign string) error {
 		return &ValidationError{Op: "verify", Msg: "X-Sign header is empty"}
 	}
 
-	pub, err := c.ensureWebhookPublicKey(context.Background())
+	keys, err := c.webhookPublicKeys(ctx)
 	if err != nil {
 		logger.Error("Webhook verify: cannot resolve public key: %v", err)
 		return err
@@ -356,12 +1118,25 @@ func (c *client) VerifyWebhook(body []byte, xSign string) error {
 	}
 
 	h := sha256.Sum256(body)
-	if !ecdsa.VerifyASN1(pub, h[:], sig) {
-		logger.Warn("Webhook verify: invalid signature")
-		return &WebhookSignatureError{Op: "verify", Msg: "invalid signature"}
+	if verifyWithAnyKey(keys, h[:], sig) {
+		logger.Info("Webhook verify: signature is valid")
+		return nil
 	}
-	logger.Info("Webhook verify: signature is valid")
-	return nil
+
+	// Key may have been rotated: refetch it once and retry verification.
+	changed, err := c.refetchWebhookPublicKey(ctx, keys[0])
+	if err != nil {
+		logger.Warn("Webhook verify: public key refetch failed: %v", err)
+	}
+	if changed {
+		if keys, err = c.webhookPublicKeys(ctx); err == nil && verifyWithAnyKey(keys, h[:], sig) {
+			logger.Info("Webhook verify: signature is valid with rotated key")
+			return nil
+		}
+	}
+
+	logger.Warn("Webhook verify: invalid signature")
+	return &WebhookSignatureError{Op: "verify", Msg: "invalid signature"}
 }
 
 // --- internal helpers ---
@@ -379,6 +1154,45 @@ func (c *client) resolveToken(request *Request) string {
 }
 
 func (c *client) doJSON(ctx context.Context, method, path string, token string, request *Request, payload any, out any) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var policy *RetryPolicy
+	if c != nil && c.cfg != nil {
+		policy = c.cfg.retry
+	}
+	if !policy.allows(method) {
+		return c.doJSONAttempt(ctx, method, path, token, request, payload, out, 1)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.doJSONAttempt(ctx, method, path, token, request, payload, out, attempt)
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.Retryable(err) {
+			return err
+		}
+
+		delay, ok := policy.delay(attempt, err)
+		if !ok {
+			logger.Warn("HTTP retry: method=%s path=%s retry_after=%s exceeds max, giving up", method, path, delay)
+			return err
+		}
+		logger.Warn(
+			"HTTP retry: method=%s path=%s attempt=%d/%d delay=%s err=%v",
+			method,
+			path,
+			attempt+1,
+			policy.MaxAttempts,
+			delay,
+			err,
+		)
+		if !sleepContext(ctx, delay) {
+			return err
+		}
+	}
+}
+
+func (c *client) doJSONAttempt(ctx context.Context, method, path string, token string, request *Request, payload any, out any, attempt int) error {
 	// Base URL comes from client config (WithBaseURL). If it's empty, fall back to default.
 	baseURL := ""
 	if c != nil && c.cfg != nil {
@@ -389,17 +1203,25 @@ func (c *client) doJSON(ctx context.Context, method, path string, token string,
 	}
 	endpoint := baseURL + path
 	requestID := recorderRequestID()
-	recordTags := recorderTags(method, path, request, 0)
+	operation, _ := ctx.Value(recorderOperationKey{}).(string)
+	recordTags := attemptTags(recorderTags(operation, method, path, request, 0), attempt)
 
 	logger.Info("HTTP request: method=%s path=%s", method, path)
-	logger.Debug("HTTP request: endpoint=%s", endpoint)
+	logger.Debug("HTTP request: endpoint=%s", c.redactURL(endpoint))
+
+	// Payloads carrying raw card data are logged and recorded in redacted form only.
+	loggedPayload := payload
+	if r, ok := payload.(redactable); ok {
+		loggedPayload = r.redacted()
+	}
 
 	var requestBody []byte
 	if payload == nil {
 		logger.Debug("HTTP request: payload=<nil>")
-	} else if body, err := json.Marshal(payload); err != nil {
+	} else if body, err := json.Marshal(loggedPayload); err != nil {
 		logger.Debug("HTTP request: payload marshal error for %T: %v", payload, err)
 	} else {
+		body = c.redact(body)
 		requestBody = body
 		logger.Debug("HTTP request: payload=%s", trimBody(body, 4096))
 	}
@@ -419,11 +1241,20 @@ func (c *client) doJSON(ctx context.Context, method, path string, token string,
 		return err
 	}
 
+	if c != nil && c.cfg != nil && c.cfg.rateLimiter != nil {
+		if err := c.cfg.rateLimiter.wait(ctx, tok, path); err != nil {
+			logger.Warn("HTTP request: client-side rate limit method=%s path=%s err=%v", method, path, err)
+			c.recordError(ctx, requestID, err, recordTags)
+			return err
+		}
+	}
+
 	// Apply timeout from client config. If timeout is 0, internalhttp.WithTimeout returns original ctx.
 	timeout := time.Duration(0)
 	if c != nil && c.cfg != nil && c.cfg.httpOptions != nil {
 		timeout = c.cfg.httpOptions.Timeout
 	}
+	callerCtx := ctx
 	ctx, cancel := internalhttp.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -454,9 +1285,36 @@ func (c *client) doJSON(ctx context.Context, method, path string, token string,
 		return clientErr
 	}
 
-	c.recordRequest(ctx, requestID, requestPayload(requestBody, method, endpoint), recordTags)
+	var breaker *circuitBreaker
+	if c.cfg != nil {
+		breaker = c.cfg.breaker
+	}
+	if breaker != nil {
+		if err := breaker.allow(path); err != nil {
+			logger.Warn("HTTP request: circuit open method=%s path=%s", method, path)
+			c.recordError(ctx, requestID, err, recordTags)
+			return err
+		}
+	}
+
+	c.recordRequest(ctx, requestID, requestPayload(requestBody, method, c.redactURL(endpoint)), recordTags)
 
 	resp, body, err := c.http.Do(req)
+	if errors.Is(err, internalhttp.ErrNilResponse) {
+		resp, err = nil, nil
+	}
+	if breaker != nil {
+		switch {
+		case callerCtx.Err() != nil:
+			breaker.record(path, circuitIgnored)
+		case err != nil || (resp != nil && resp.StatusCode >= 500):
+			breaker.record(path, circuitFailure)
+		case resp == nil:
+			breaker.record(path, circuitIgnored)
+		default:
+			breaker.record(path, circuitSuccess)
+		}
+	}
 	if err != nil {
 		logger.Error("HTTP request: transport error method=%s path=%s err=%v", method, path, err)
 		transportErr := &TransportError{Op: "http.do", Method: method, URL: endpoint, Cause: err}
@@ -470,11 +1328,13 @@ func (c *client) doJSON(ctx context.Context, method, path string, token string,
 		return nilRespErr
 	}
 	logger.Info("HTTP response: method=%s path=%s status=%d", method, path, resp.StatusCode)
-	logger.Debug("HTTP response: method=%s path=%s body=%s", method, path, trimBody(body, 4096))
-	c.recordResponse(ctx, requestID, responsePayload(body, resp.StatusCode), recorderTags(method, path, request, resp.StatusCode))
+	// Everything that leaves the SDK (logs, recorder, error bodies) uses the redacted body.
+	redactedBody := c.redact(body)
+	logger.Debug("HTTP response: method=%s path=%s body=%s", method, path, trimBody(redactedBody, 4096))
+	c.recordResponse(ctx, requestID, responsePayload(redactedBody, resp.StatusCode), attemptTags(recorderTags(operation, method, path, request, resp.StatusCode), attempt))
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		errCode, desc := parseAPIErrorBody(body)
+		errCode, desc := parseAPIErrorBody(redactedBody)
 
 		apiErr := &APIError{
 			Kind:        kindFromStatus(resp.StatusCode),
@@ -484,7 +1344,7 @@ func (c *client) doJSON(ctx context.Context, method, path string, token string,
 			ContentType: resp.Header.Get("Content-Type"),
 			ErrCode:     errCode,
 			Description: desc,
-			Body:        trimBody(body, 4096),
+			Body:        trimBody(redactedBody, 4096),
 		}
 
 		if resp.StatusCode == 429 {
@@ -514,8 +1374,11 @@ func (c *client) doJSON(ctx context.Context, method, path string, token string,
 		}
 		if apiErr.RetryAfter != nil {
 			logger.Warn("HTTP response: retry_after=%s for method=%s path=%s", apiErr.RetryAfter.String(), method, path)
+			if c.cfg != nil && c.cfg.rateLimiter != nil {
+				c.cfg.rateLimiter.pause(tok, path, *apiErr.RetryAfter)
+			}
 		}
-		c.recordError(ctx, requestID, apiErr, recorderTags(method, path, request, resp.StatusCode))
+		c.recordError(ctx, requestID, apiErr, attemptTags(recorderTags(operation, method, path, request, resp.StatusCode), attempt))
 		return apiErr
 	}
 
@@ -526,13 +1389,13 @@ func (c *client) doJSON(ctx context.Context, method, path string, token string,
 	if len(body) == 0 {
 		logger.Error("HTTP response: empty body method=%s path=%s status=%d", method, path, resp.StatusCode)
 		decodeErr := &UnexpectedResponseError{Op: "decode", Method: method, Endpoint: path, StatusCode: resp.StatusCode, Msg: "empty response body"}
-		c.recordError(ctx, requestID, decodeErr, recorderTags(method, path, request, resp.StatusCode))
+		c.recordError(ctx, requestID, decodeErr, attemptTags(recorderTags(operation, method, path, request, resp.StatusCode), attempt))
 		return decodeErr
 	}
 	if err := json.Unmarshal(body, out); err != nil {
 		logger.Error("HTTP response: decode error method=%s path=%s err=%v", method, path, err)
-		decodeErr := &DecodeError{Op: "decode", Msg: "json unmarshal response", Body: trimBody(body, 4096), Cause: err}
-		c.recordError(ctx, requestID, decodeErr, recorderTags(method, path, request, resp.StatusCode))
+		decodeErr := &DecodeError{Op: "decode", Msg: "json unmarshal response", Body: trimBody(redactedBody, 4096), Cause: err}
+		c.recordError(ctx, requestID, decodeErr, attemptTags(recorderTags(operation, method, path, request, resp.StatusCode), attempt))
 		return decodeErr
 	}
 	logger.Debug("HTTP response: decoded into %T", out)
@@ -540,6 +1403,31 @@ func (c *client) doJSON(ctx context.Context, method, path string, token string,
 	return nil
 }
 
+// redact masks secrets and PII in body according to WithRedaction.
+func (c *client) redact(body []byte) []byte {
+	if c == nil || c.cfg == nil {
+		return body
+	}
+	return c.cfg.redactor.body(body)
+}
+
+// redactURL masks sensitive query parameters of endpoint according to WithRedaction.
+func (c *client) redactURL(endpoint string) string {
+	if c == nil || c.cfg == nil {
+		return endpoint
+	}
+	return c.cfg.redactor.url(endpoint)
+}
+
+// handleDryRun passes payload to DryRun handler; the default handler prints it redacted.
+func (c *client) handleDryRun(opts *runOptions, endpoint string, payload any) {
+	if opts.usesDefaultDryRunHandler() && c != nil && c.cfg != nil {
+		endpoint = c.cfg.redactor.url(endpoint)
+		payload = c.cfg.redactor.payload(payload)
+	}
+	opts.handleDryRun(endpoint, payload)
+}
+
 func (c *client) recordRequest(ctx context.Context, requestID string, payload []byte, tags map[string]string) {
 	if c == nil || c.cfg == nil || c.cfg.recorder == nil || len(payload) == 0 {
 		return
@@ -603,14 +1491,34 @@ func responsePayload(body []byte, statusCode int) []byte {
 	return encoded
 }
 
-func recorderTags(method, path string, request *Request, statusCode int) map[string]string {
+// attemptTags marks records of retried calls so every attempt can be told apart.
+func attemptTags(tags map[string]string, attempt int) map[string]string {
+	if attempt > 1 {
+		tags["attempt"] = strconv.Itoa(attempt)
+	}
+	return tags
+}
+
+// recorderOperationKey carries the recorder "operation" tag for endpoints shared by
+// several operations (see withRecorderOperation).
+type recorderOperationKey struct{}
+
+func withRecorderOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, recorderOperationKey{}, operation)
+}
+
+// recorderTags builds recorder tags; empty operation is derived from path.
+func recorderTags(operation, method, path string, request *Request, statusCode int) map[string]string {
 	cleanPath := normalizeRecorderPath(path)
 	tags := map[string]string{
 		"gateway": "monobank",
 		"method":  strings.ToUpper(strings.TrimSpace(method)),
 		"path":    cleanPath,
 	}
-	if operation := recorderOperation(cleanPath); operation != "" {
+	if operation == "" {
+		operation = recorderOperation(cleanPath)
+	}
+	if operation != "" {
 		tags["operation"] = operation
 	}
 
@@ -619,6 +1527,12 @@ func recorderTags(method, path string, request *Request, statusCode int) map[str
 		if invoiceID := strings.TrimSpace(request.GetInvoiceID()); invoiceID != "" {
 			tags["invoice_id"] = invoiceID
 		}
+		if extRef := request.GetExtRef(); extRef != "" {
+			tags["ext_ref"] = extRef
+		}
+		if qrID := request.GetQrIDValue(); qrID != "" {
+			tags["qr_id"] = qrID
+		}
 		if payInfo := request.GetMerchantPaymInfo(); payInfo != nil {
 			if ref := strings.TrimSpace(payInfo.Reference); ref != "" {
 				tags["reference"] = ref
@@ -653,16 +1567,42 @@ func recorderOperation(path string) string {
 	switch path {
 	case consts.PathWalletPayment:
 		return "payment"
-	case consts.PathInvoiceCreate:
-		return "verification"
+	case consts.PathInvoicePaymentDirect:
+		return "payment_direct"
 	case consts.PathInvoiceStatus:
 		return "status"
+	case consts.PathInvoiceCancel:
+		return "cancel"
+	case consts.PathInvoiceFinalize:
+		return "finalize"
+	case consts.PathInvoiceRemove:
+		return "remove"
 	case consts.PathInvoiceFiscalChecks:
 		return "fiscal_checks"
+	case consts.PathInvoicePaymentInfo:
+		return "payment_info"
 	case consts.PathWallet:
 		return "wallet"
+	case consts.PathWalletCard:
+		return "wallet_card_delete"
 	case consts.PathPubKey:
 		return "pubkey"
+	case consts.PathStatement:
+		return "statement"
+	case consts.PathMerchantDetails:
+		return "merchant_details"
+	case consts.PathSubMerchantList:
+		return "submerchant_list"
+	case consts.PathEmployeeList:
+		return "employee_list"
+	case consts.PathSplitReceiverList:
+		return "split_receiver_list"
+	case consts.PathQRList:
+		return "qr_list"
+	case consts.PathQRDetails:
+		return "qr_details"
+	case consts.PathQRResetAmount:
+		return "qr_reset_amount"
 	default:
 		return ""
 	}
@@ -806,6 +1746,12 @@ func mapToInvoiceCreatePayload(r *Request, amount int64, ccy CurrencyCode) any {
 		Validity         *int64            `json:"validity,omitempty"`
 		PaymentType      PaymentType       `json:"paymentType,omitempty"`
 		SaveCardData     *SaveCardData     `json:"saveCardData,omitempty"`
+		QrID             *string           `json:"qrId,omitempty"`
+		Code             *string           `json:"code,omitempty"`
+		AgentFeePercent  *float64          `json:"agentFeePercent,omitempty"`
+		DisplayType      DisplayType       `json:"displayType,omitempty"`
+		TipsEmployeeID   *string           `json:"tipsEmployeeId,omitempty"`
+		Splits           []Split           `json:"splits,omitempty"`
 	}{
 		Amount:   amount,
 		Currency: ccy,
@@ -823,6 +1769,88 @@ func mapToInvoiceCreatePayload(r *Request, amount int64, ccy CurrencyCode) any {
 		if r.ShouldSaveCard() {
 			payload.SaveCardData = &SaveCardData{SaveCard: true, WalletID: r.GetWalletID()}
 		}
+		payload.QrID = r.GetQrID()
+		payload.Code = r.GetCode()
+		payload.AgentFeePercent = r.GetAgentFeePercent()
+		payload.DisplayType = r.GetDisplayType()
+		payload.TipsEmployeeID = r.GetTipsEmployeeID()
+		payload.Splits = r.GetSplits()
+	}
+	return payload
+}
+
+func mapToInvoiceCancelPayload(r *Request, invoiceID string, amount int64) any {
+	payload := struct {
+		InvoiceID string       `json:"invoiceId"`
+		ExtRef    string       `json:"extRef,omitempty"`
+		Amount    int64        `json:"amount,omitempty"`
+		Items     []BasketItem `json:"items,omitempty"`
+	}{
+		InvoiceID: invoiceID,
+		Amount:    amount,
+	}
+
+	if r != nil {
+		payload.ExtRef = r.GetExtRef()
+		payload.Items = r.GetItems()
+	}
+	return payload
+}
+
+func mapToInvoiceFinalizePayload(r *Request, invoiceID string, amount int64) any {
+	payload := struct {
+		InvoiceID string       `json:"invoiceId"`
+		Amount    int64        `json:"amount,omitempty"`
+		Items     []BasketItem `json:"items,omitempty"`
+	}{
+		InvoiceID: invoiceID,
+		Amount:    amount,
+	}
+
+	if r != nil {
+		payload.Items = r.GetItems()
+	}
+	return payload
+}
+
+// redactable is implemented by payloads that must never reach logs or recorder in raw form.
+type redactable interface {
+	redacted() any
+}
+
+type paymentDirectPayload struct {
+	Amount           int64             `json:"amount"`
+	Currency         CurrencyCode      `json:"ccy"`
+	CardData         CardData          `json:"cardData"`
+	PaymentType      PaymentType       `json:"paymentType,omitempty"`
+	InitiationKind   InitiationKind    `json:"initiationKind,omitempty"`
+	RedirectURL      *string           `json:"redirectUrl,omitempty"`
+	WebHookURL       *string           `json:"webHookUrl,omitempty"`
+	MerchantPaymInfo *MerchantPaymInfo `json:"merchantPaymInfo,omitempty"`
+	SaveCardData     *SaveCardData     `json:"saveCardData,omitempty"`
+}
+
+func (p paymentDirectPayload) redacted() any {
+	p.CardData = p.CardData.Masked()
+	return p
+}
+
+func mapToPaymentDirectPayload(r *Request, card CardData, amount int64, ccy CurrencyCode, paymentType PaymentType) paymentDirectPayload {
+	payload := paymentDirectPayload{
+		Amount:      amount,
+		Currency:    ccy,
+		CardData:    card,
+		PaymentType: paymentType,
+	}
+
+	if r != nil {
+		payload.InitiationKind = r.GetInitiationKind()
+		payload.RedirectURL = r.GetRedirectURL()
+		payload.WebHookURL = r.GetWebHookURL()
+		payload.MerchantPaymInfo = r.GetMerchantPaymInfo()
+		if r.ShouldSaveCard() {
+			payload.SaveCardData = &SaveCardData{SaveCard: true, WalletID: r.GetWalletID()}
+		}
 	}
 	return payload
 }
@@ -877,6 +1905,7 @@ func mapToWalletPaymentPayload(
 		InitiationKind   InitiationKind    `json:"initiationKind"`
 		MerchantPaymInfo *MerchantPaymInfo `json:"merchantPaymInfo,omitempty"`
 		PaymentType      PaymentType       `json:"paymentType,omitempty"`
+		Splits           []Split           `json:"splits,omitempty"`
 	}{
 		CardToken:      strings.TrimSpace(source.CardToken),
 		AToken:         strings.TrimSpace(source.AToken),
@@ -890,67 +1919,11 @@ func mapToWalletPaymentPayload(
 		payload.RedirectURL = r.GetRedirectURL()
 		payload.WebHookURL = r.GetWebHookURL()
 		payload.MerchantPaymInfo = r.GetMerchantPaymInfo()
+		payload.Splits = r.GetSplits()
 	}
 	return payload
 }
 
-func (c *client) ensureWebhookPublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
-	c.pubKeyMu.Lock()
-	defer c.pubKeyMu.Unlock()
-
-	if c.pubKey != nil {
-		return c.pubKey, nil
-	}
-
-	// 1) Raw PEM provided
-	if len(c.cfg.webhookPublicKeyPEM) > 0 {
-		pub, err := parseECDSAPublicKeyFromPEM(c.cfg.webhookPublicKeyPEM)
-		if err != nil {
-			return nil, fmt.Errorf("pubkey: parse PEM: %w", err)
-		}
-		c.pubKey = pub
-		return pub, nil
-	}
-
-	// 2) Base64 PEM provided
-	if strings.TrimSpace(c.cfg.webhookPublicKeyBase64) != "" {
-		pemBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(c.cfg.webhookPublicKeyBase64))
-		if err != nil {
-			return nil, fmt.Errorf("pubkey: base64 decode: %w", err)
-		}
-		pub, err := parseECDSAPublicKeyFromPEM(pemBytes)
-		if err != nil {
-			return nil, fmt.Errorf("pubkey: parse decoded PEM: %w", err)
-		}
-		c.pubKey = pub
-		return pub, nil
-	}
-
-	// 3) Fetch from API using default token
-	token := strings.TrimSpace(c.cfg.defaultToken)
-	if token == "" {
-		return nil, &ValidationError{Op: "pubkey", Msg: "public key not configured and default token is empty; set WithWebhookPublicKeyBase64(...) or WithToken(...)"}
-	}
-	var resp PublicKeyResponse
-	if err := c.doJSON(ctx, http.MethodGet, consts.PathPubKey, token, nil, nil, &resp); err != nil {
-		return nil, err
-	}
-	if strings.TrimSpace(resp.Key) == "" {
-		return nil, fmt.Errorf("pubkey: empty key in response")
-	}
-	pemBytes, err := base64.StdEncoding.DecodeString(strings.TrimSpace(resp.Key))
-	if err != nil {
-		return nil, fmt.Errorf("pubkey: base64 decode fetched key: %w", err)
-	}
-	pub, err := parseECDSAPublicKeyFromPEM(pemBytes)
-	if err != nil {
-		return nil, fmt.Errorf("pubkey: parse fetched PEM: %w", err)
-	}
-	c.cfg.webhookPublicKeyBase64 = strings.TrimSpace(resp.Key)
-	c.pubKey = pub
-	return pub, nil
-}
-
 func parseECDSAPublicKeyFromPEM(pemBytes []byte) (*ecdsa.PublicKey, error) {
 	block, _ := pem.Decode(pemBytes)
 	if block == nil {
@@ -131,3 +131,79 @@ func TestVerificationKeepsPositiveAmountForDebitAndHoldPaymentTypes(t *testing.T
 		)
 	}
 }
+
+func TestCreateInvoiceDryRunIncludesHostedCheckoutFields(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	request := NewRequest().
+		WithAmount(4200).
+		WithQrID("qr-1").
+		WithCode("shop-2").
+		WithAgentFeePercent(1.5).
+		WithDisplayType(DisplayTypeIframe).
+		WithTipsEmployeeID("emp-7").
+		WithReference("order-7")
+
+	var payload any
+	_, err := client.CreateInvoice(request, DryRun(func(_ string, gotPayload any) { payload = gotPayload }))
+	if err != nil {
+		t.Fatalf("CreateInvoice() unexpected error: %v", err)
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal dry-run payload: %v", err)
+	}
+
+	var got map[string]any
+	if err = json.Unmarshal(payloadJSON, &got); err != nil {
+		t.Fatalf("unmarshal dry-run payload: %v", err)
+	}
+
+	want := map[string]any{
+		"amount":          float64(4200),
+		"ccy":             float64(CurrencyUAH),
+		"paymentType":     string(PaymentTypeDebit),
+		"qrId":            "qr-1",
+		"code":            "shop-2",
+		"agentFeePercent": 1.5,
+		"displayType":     "iframe",
+		"tipsEmployeeId":  "emp-7",
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Fatalf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+	if _, ok := got["saveCardData"]; ok {
+		t.Fatalf("saveCardData must be omitted for regular invoice: %s", payloadJSON)
+	}
+}
+
+func TestCreateInvoiceRejectsInvalidOptionalFields(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	tests := map[string]*Request{
+		"zero amount":       NewRequest(),
+		"agent fee > 100":   NewRequest().WithAmount(100).WithAgentFeePercent(101),
+		"unknown display":   NewRequest().WithAmount(100).WithDisplayType("popup"),
+		"wallet id missing": NewRequest().WithAmount(100).EnableSaveCard(),
+		"verification type": NewRequest().WithPaymentType(PaymentTypeVerification).SaveCard("wallet-1"),
+	}
+
+	for name, request := range tests {
+		request := request
+		t.Run(
+			name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := client.CreateInvoice(request, DryRun())
+				if !errors.Is(err, ErrValidation) {
+					t.Fatalf("expected ErrValidation, got %v", err)
+				}
+			},
+		)
+	}
+}
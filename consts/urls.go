@@ -3,10 +3,24 @@ package consts
 const (
 	DefaultBaseURL = "https://api.monobank.ua"
 
-	PathInvoiceCreate       = "/api/merchant/invoice/create"
-	PathInvoiceStatus       = "/api/merchant/invoice/status"
-	PathInvoiceFiscalChecks = "/api/merchant/invoice/fiscal-checks"
-	PathWallet              = "/api/merchant/wallet"
-	PathWalletPayment       = "/api/merchant/wallet/payment"
-	PathPubKey              = "/api/merchant/pubkey"
+	PathInvoiceCreate        = "/api/merchant/invoice/create"
+	PathInvoiceStatus        = "/api/merchant/invoice/status"
+	PathInvoiceCancel        = "/api/merchant/invoice/cancel"
+	PathInvoiceFinalize      = "/api/merchant/invoice/finalize"
+	PathInvoiceRemove        = "/api/merchant/invoice/remove"
+	PathInvoicePaymentDirect = "/api/merchant/invoice/payment-direct"
+	PathInvoiceFiscalChecks  = "/api/merchant/invoice/fiscal-checks"
+	PathInvoicePaymentInfo   = "/api/merchant/invoice/payment-info"
+	PathWallet               = "/api/merchant/wallet"
+	PathWalletPayment        = "/api/merchant/wallet/payment"
+	PathWalletCard           = "/api/merchant/wallet/card"
+	PathPubKey               = "/api/merchant/pubkey"
+	PathStatement            = "/api/merchant/statement"
+	PathMerchantDetails      = "/api/merchant/details"
+	PathSubMerchantList      = "/api/merchant/submerchant/list"
+	PathEmployeeList         = "/api/merchant/employee/list"
+	PathSplitReceiverList    = "/api/merchant/split-receiver/list"
+	PathQRList               = "/api/merchant/qr/list"
+	PathQRDetails            = "/api/merchant/qr/details"
+	PathQRResetAmount        = "/api/merchant/qr/reset-amount"
 )
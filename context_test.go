@@ -0,0 +1,113 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newBlockingServer(t *testing.T) (*httptest.Server, <-chan struct{}) {
+	t.Helper()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case started <- struct{}{}:
+				default:
+				}
+				select {
+				case <-r.Context().Done():
+				case <-release:
+				}
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+	// Cleanups run in LIFO order: unblock handlers before closing the server.
+	t.Cleanup(func() { close(release) })
+	return server, started
+}
+
+func TestStatusContextCancellationAbortsInFlightCall(t *testing.T) {
+	t.Parallel()
+
+	server, started := newBlockingServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.StatusContext(ctx, NewRequest().WithInvoiceID("inv-1"))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrTransport) {
+			t.Fatalf("expected ErrTransport, got %v", err)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("StatusContext() did not return after cancellation")
+	}
+}
+
+func TestPaymentContextDeadlineAbortsInFlightCall(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newBlockingServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	request := NewRequest().
+		WithCardToken("card-token").
+		WithAmount(100).
+		WithInitiationKind(InitiationMerchant)
+
+	_, err := client.PaymentContext(ctx, request)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestVerifyWebhookContextPropagatesToPublicKeyFetch(t *testing.T) {
+	t.Parallel()
+
+	server, started := newBlockingServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.VerifyWebhookContext(ctx, []byte(`{"invoiceId":"inv-1"}`), "c2lnbmF0dXJl")
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("VerifyWebhookContext() did not return after cancellation")
+	}
+}
@@ -27,6 +27,15 @@ var (
 	ErrMethodNotAllowed = errors.New("monobank: method not allowed")
 	// ErrRateLimited corresponds to HTTP 429 from API.
 	ErrRateLimited = errors.New("monobank: rate limited")
+	// ErrClientRateLimited is returned when the client-side rate limiter (WithRateLimiter)
+	// cannot admit a request before the caller's context is done. No request is sent.
+	ErrClientRateLimited = errors.New("monobank: client-side rate limit")
+	// ErrCircuitOpen is returned without calling API while circuit breaker (WithCircuitBreaker)
+	// of the endpoint is open after consecutive transport/server failures.
+	ErrCircuitOpen = errors.New("monobank: circuit open")
+	// ErrReplayMismatch is returned by ReplayTransport when no recorded interaction
+	// matches the outgoing request.
+	ErrReplayMismatch = errors.New("monobank: no recorded interaction matches request")
 	// ErrServerError corresponds to HTTP 5xx from API.
 	ErrServerError = errors.New("monobank: server error")
 	// ErrUnexpectedResponse is returned when API responds in an unexpected way (unknown status code, invalid content).
@@ -35,6 +44,13 @@ var (
 	// ErrInvalidSignature is returned when webhook signature validation fails (X-Sign).
 	ErrInvalidSignature = errors.New("monobank: invalid webhook signature")
 
+	// ErrInvoiceAlreadyFinal is returned by RemoveInvoice when invoice has already reached a final state
+	// (paid, expired, removed). It is non-fatal: the link is not payable anymore either way.
+	ErrInvoiceAlreadyFinal = errors.New("monobank: invoice already final")
+
+	// ErrWebhookHandler is returned by WebhookDispatcher when a registered handler fails or panics.
+	ErrWebhookHandler = errors.New("monobank: webhook handler error")
+
 	// ErrPaymentError indicates a business/payment failure (errCode/failureReason from webhook/status).
 	ErrPaymentError = errors.New("monobank: payment error")
 )
@@ -256,6 +272,83 @@ func (e *APIError) Is(target error) bool {
 	return false
 }
 
+// RateLimitWaitError indicates that the client-side rate limiter rejected a request
+// because the required wait exceeds the caller's context deadline (or ctx was canceled).
+type RateLimitWaitError struct {
+	Endpoint string
+	// Wait is how long the request would have to wait for its turn.
+	Wait  time.Duration
+	Cause error
+}
+
+func (e *RateLimitWaitError) Error() string {
+	if e == nil {
+		return ErrClientRateLimited.Error()
+	}
+	base := ErrClientRateLimited.Error()
+	if e.Endpoint != "" {
+		base += ": " + e.Endpoint
+	}
+	base += fmt.Sprintf(": wait=%s", e.Wait.String())
+	if e.Cause != nil {
+		base += ": " + e.Cause.Error()
+	}
+	return base
+}
+
+func (e *RateLimitWaitError) Unwrap() error { return e.Cause }
+func (e *RateLimitWaitError) Is(target error) bool {
+	return target == ErrClientRateLimited
+}
+
+// CircuitOpenError indicates that request was rejected by an open circuit breaker.
+type CircuitOpenError struct {
+	Endpoint string
+	// RetryAt is when the circuit lets a probe request through.
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	if e == nil {
+		return ErrCircuitOpen.Error()
+	}
+	base := ErrCircuitOpen.Error()
+	if e.Endpoint != "" {
+		base += ": " + e.Endpoint
+	}
+	if !e.RetryAt.IsZero() {
+		base += ": retryAt=" + e.RetryAt.Format(time.RFC3339)
+	}
+	return base
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// ReplayMismatchError describes a request ReplayTransport could not serve.
+type ReplayMismatchError struct {
+	Method string
+	// Path is the normalized path (see ReplayTransport), including sorted query.
+	Path string
+	Body string
+}
+
+func (e *ReplayMismatchError) Error() string {
+	if e == nil {
+		return ErrReplayMismatch.Error()
+	}
+	base := ErrReplayMismatch.Error() + ": " + e.Method + " " + e.Path
+	if e.Body != "" {
+		base += " body=" + string(trimBody([]byte(e.Body), 512))
+	}
+	return base
+}
+
+func (e *ReplayMismatchError) Is(target error) bool {
+	return target == ErrReplayMismatch
+}
+
 // WebhookSignatureError indicates that webhook signature verification failed.
 type WebhookSignatureError struct {
 	Op    string
@@ -285,6 +378,103 @@ func (e *WebhookSignatureError) Is(target error) bool {
 	return target == ErrInvalidSignature
 }
 
+// WebhookHandlerError is a failure of a single WebhookDispatcher handler.
+type WebhookHandlerError struct {
+	// Event is the dispatcher event name: success, failure, reversed, hold or tokenized.
+	Event string
+	Err   error
+	// Panic is the recovered value when handler panicked.
+	Panic any
+}
+
+func (e *WebhookHandlerError) Error() string {
+	if e == nil {
+		return ErrWebhookHandler.Error()
+	}
+	base := ErrWebhookHandler.Error()
+	if e.Event != "" {
+		base += ": " + e.Event
+	}
+	if e.Panic != nil {
+		base += fmt.Sprintf(": panic: %v", e.Panic)
+	}
+	if e.Err != nil {
+		base += ": " + e.Err.Error()
+	}
+	return base
+}
+
+func (e *WebhookHandlerError) Unwrap() error { return e.Err }
+func (e *WebhookHandlerError) Is(target error) bool {
+	return target == ErrWebhookHandler
+}
+
+// WebhookDispatchError aggregates all handler failures of one dispatched event.
+// errors.Is/As see through to every handler error.
+type WebhookDispatchError struct {
+	InvoiceID string
+	Status    InvoiceStatus
+	Errors    []*WebhookHandlerError
+}
+
+func (e *WebhookDispatchError) Error() string {
+	if e == nil {
+		return ErrWebhookHandler.Error()
+	}
+	base := fmt.Sprintf("%s: invoiceId=%s status=%s", ErrWebhookHandler.Error(), e.InvoiceID, e.Status)
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	if len(msgs) > 0 {
+		base += ": [" + strings.Join(msgs, "; ") + "]"
+	}
+	return base
+}
+
+func (e *WebhookDispatchError) Unwrap() []error {
+	if e == nil {
+		return nil
+	}
+	out := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		out = append(out, err)
+	}
+	return out
+}
+
+// InvoiceAlreadyFinalError indicates that invoice could not be removed because it is already final.
+// It wraps the underlying APIError.
+type InvoiceAlreadyFinalError struct {
+	InvoiceID string
+	Cause     *APIError
+}
+
+func (e *InvoiceAlreadyFinalError) Error() string {
+	if e == nil {
+		return ErrInvoiceAlreadyFinal.Error()
+	}
+	base := ErrInvoiceAlreadyFinal.Error()
+	if strings.TrimSpace(e.InvoiceID) != "" {
+		base += ": invoiceId=" + strings.TrimSpace(e.InvoiceID)
+	}
+	if e.Cause != nil {
+		base += ": " + e.Cause.Error()
+	}
+	return base
+}
+
+func (e *InvoiceAlreadyFinalError) Unwrap() error {
+	if e == nil || e.Cause == nil {
+		return nil
+	}
+	return e.Cause
+}
+
+func (e *InvoiceAlreadyFinalError) Is(target error) bool {
+	return target == ErrInvoiceAlreadyFinal
+}
+
 // --- internal helpers ---
 
 func kindFromStatus(status int) error {
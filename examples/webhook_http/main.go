@@ -1,8 +1,8 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"os"
@@ -23,29 +23,15 @@ func main() {
 
 	client.SetLogLevel(log2.Debug)
 
-	h := func(w http.ResponseWriter, r *http.Request) {
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = w.Write([]byte("bad body"))
-			return
-		}
-
-		xSign := r.Header.Get("X-Sign")
-		event, err := client.ParseAndVerifyWebhook(body, xSign)
-		if err != nil {
-			// invalid signature or invalid JSON
-			w.WriteHeader(http.StatusUnauthorized)
-			_, _ = w.Write([]byte(err.Error()))
-			return
-		}
-
-		fmt.Printf("webhook: invoiceId=%s status=%s\n", event.InvoiceID, event.Status)
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
-	}
+	h := go_monobank.WebhookHandler(
+		client,
+		func(ctx context.Context, event *go_monobank.InvoiceStatusResponse) error {
+			fmt.Printf("webhook: invoiceId=%s status=%s\n", event.InvoiceID, event.Status)
+			return nil
+		},
+	)
 
-	http.HandleFunc("/webhook", h)
+	http.Handle("/webhook", h)
 	addr := ":8081"
 	fmt.Println("listening on", addr)
 	log.Fatal(http.ListenAndServe(addr, nil))
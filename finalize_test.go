@@ -0,0 +1,123 @@
+package go_monobank
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFinalizeRejectsAmountGreaterThanHeld(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	hold := &WalletPaymentResponse{InvoiceID: "inv-1", Status: InvoiceSuccess, Amount: 1000, Currency: CurrencyUAH}
+
+	_, err := client.Finalize(NewRequest().ForHold(hold).WithAmount(1001), DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestFinalizeDryRunPartialAmount(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	hold := &WalletPaymentResponse{InvoiceID: "inv-1", Status: InvoiceSuccess, Amount: 1000, Currency: CurrencyUAH}
+	request := NewRequest().
+		ForHold(hold).
+		WithAmount(600).
+		AddItem(BasketItem{Name: "Tea", Qty: 2, Sum: 300, Code: "tea-1"})
+
+	var endpoint string
+	var payload any
+	_, err := client.Finalize(
+		request,
+		DryRun(
+			func(gotEndpoint string, gotPayload any) {
+				endpoint = gotEndpoint
+				payload = gotPayload
+			},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Finalize() unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(endpoint, "/api/merchant/invoice/finalize") {
+		t.Fatalf("unexpected dry-run endpoint: %s", endpoint)
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal dry-run payload: %v", err)
+	}
+
+	var got map[string]any
+	if err = json.Unmarshal(payloadJSON, &got); err != nil {
+		t.Fatalf("unmarshal dry-run payload: %v", err)
+	}
+	if got["invoiceId"] != "inv-1" || got["amount"] != float64(600) {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+	if items, ok := got["items"].([]any); !ok || len(items) != 1 {
+		t.Fatalf("unexpected items: %+v", got["items"])
+	}
+}
+
+func TestReleaseHoldRejectsPartialAmount(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+
+	_, err := client.ReleaseHold(NewRequest().WithInvoiceID("inv-1").WithAmount(100), DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestFinalizeAndReleaseHoldCallAPI(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/api/merchant/invoice/finalize":
+					_, _ = w.Write([]byte(`{"status":"success"}`))
+				case "/api/merchant/invoice/cancel":
+					_, _ = w.Write([]byte(`{"status":"success","createdDate":"2026-02-26T10:00:00Z","modifiedDate":"2026-02-26T10:00:00Z"}`))
+				default:
+					t.Fatalf("unexpected path: %s", r.URL.Path)
+				}
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	finalized, err := client.Finalize(NewRequest().WithInvoiceID("inv-1"))
+	if err != nil {
+		t.Fatalf("Finalize() unexpected error: %v", err)
+	}
+	if !finalized.IsSuccess() {
+		t.Fatalf("expected finalize success, got %+v", finalized)
+	}
+
+	released, err := client.ReleaseHold(NewRequest().WithInvoiceID("inv-2"))
+	if err != nil {
+		t.Fatalf("ReleaseHold() unexpected error: %v", err)
+	}
+	if !released.IsSuccess() {
+		t.Fatalf("expected release success, got %+v", released)
+	}
+
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 API calls, got %v", paths)
+	}
+}
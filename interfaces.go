@@ -1,6 +1,8 @@
 package go_monobank
 
 import (
+	"context"
+	"iter"
 	"net/url"
 
 	"github.com/stremovskyy/go-monobank/log"
@@ -10,33 +12,142 @@ import (
 //
 // Minimal supported flows:
 //   - Verification (invoice/create + saveCardData)
-//   - Tokenized card wallet list (wallet)
+//   - Hosted-checkout invoices (invoice/create, invoice/remove)
+//   - Tokenized card wallet list and removal (wallet, wallet/card)
 //   - Payment by card token (wallet/payment)
+//   - Direct card payment (invoice/payment-direct)
 //   - Status (invoice/status)
 //   - Fiscal checks (invoice/fiscal-checks)
+//   - Payment details (invoice/payment-info)
+//   - Merchant statement (statement)
+//   - QR cash registers (qr/list, qr/details, qr/reset-amount)
+//   - Merchant details, sub-merchants and employees
+//   - Split payment receivers (split-receiver/list)
+//   - Cancellation / refunds (invoice/cancel)
+//   - Hold finalization (invoice/finalize)
 //   - Webhook parsing + signature verification (X-Sign)
+//
+// Every API call has a ...Context variant that threads the caller's context
+// into the HTTP request, so cancellation and deadlines abort in-flight calls.
+// The plain variants use context.Background().
 type Monobank interface {
 	// Verification creates an invoice with saveCardData (tokenization).
 	// It returns invoiceId + pageUrl.
 	Verification(request *Request, opts ...RunOption) (*InvoiceCreateResponse, error)
 	// VerificationLink is a helper that returns only pageUrl as parsed *url.URL.
 	VerificationLink(request *Request, opts ...RunOption) (*url.URL, error)
+	// CreateInvoice creates a regular hosted-checkout invoice (invoice/create).
+	CreateInvoice(request *Request, opts ...RunOption) (*InvoiceCreateResponse, error)
+	// CreateInvoiceContext is CreateInvoice with caller-provided context.
+	CreateInvoiceContext(ctx context.Context, request *Request, opts ...RunOption) (*InvoiceCreateResponse, error)
+	// VerificationContext is Verification with caller-provided context.
+	VerificationContext(ctx context.Context, request *Request, opts ...RunOption) (*InvoiceCreateResponse, error)
+	// VerificationLinkContext is VerificationLink with caller-provided context.
+	VerificationLinkContext(ctx context.Context, request *Request, opts ...RunOption) (*url.URL, error)
 
 	// Wallet lists tokenized cards for a merchant-defined walletId.
 	Wallet(request *Request, opts ...RunOption) (*WalletResponse, error)
+	// WalletContext is Wallet with caller-provided context.
+	WalletContext(ctx context.Context, request *Request, opts ...RunOption) (*WalletResponse, error)
+	// DeleteWalletCard removes a tokenized card by cardToken (wallet/card).
+	DeleteWalletCard(request *Request, opts ...RunOption) error
+	// DeleteWalletCardContext is DeleteWalletCard with caller-provided context.
+	DeleteWalletCardContext(ctx context.Context, request *Request, opts ...RunOption) error
+	// ClearWallet removes all tokenized cards of walletId (wallet + wallet/card).
+	ClearWallet(request *Request, opts ...RunOption) ([]WalletItem, error)
+	// ClearWalletContext is ClearWallet with caller-provided context.
+	ClearWalletContext(ctx context.Context, request *Request, opts ...RunOption) ([]WalletItem, error)
 
 	// Payment performs a charge by tokenized card or direct wallet token (wallet/payment).
 	Payment(request *Request, opts ...RunOption) (*WalletPaymentResponse, error)
 	// Hold performs a hold by tokenized card or direct wallet token (wallet/payment).
 	Hold(request *Request, opts ...RunOption) (*WalletPaymentResponse, error)
+	// PaymentContext is Payment with caller-provided context.
+	PaymentContext(ctx context.Context, request *Request, opts ...RunOption) (*WalletPaymentResponse, error)
+	// HoldContext is Hold with caller-provided context.
+	HoldContext(ctx context.Context, request *Request, opts ...RunOption) (*WalletPaymentResponse, error)
+	// PaymentDirect charges raw card data (invoice/payment-direct). PCI DSS-certified merchants only.
+	PaymentDirect(request *Request, opts ...RunOption) (*WalletPaymentResponse, error)
+	// PaymentDirectContext is PaymentDirect with caller-provided context.
+	PaymentDirectContext(ctx context.Context, request *Request, opts ...RunOption) (*WalletPaymentResponse, error)
 
 	// Status returns current invoice status (invoice/status).
 	Status(request *Request, opts ...RunOption) (*InvoiceStatusResponse, error)
 	// FiscalChecks returns PRRO fiscal checks for invoice (invoice/fiscal-checks).
 	FiscalChecks(request *Request, opts ...RunOption) (*FiscalChecksResponse, error)
+	// StatusContext is Status with caller-provided context.
+	StatusContext(ctx context.Context, request *Request, opts ...RunOption) (*InvoiceStatusResponse, error)
+	// FiscalChecksContext is FiscalChecks with caller-provided context.
+	FiscalChecksContext(ctx context.Context, request *Request, opts ...RunOption) (*FiscalChecksResponse, error)
+
+	// PaymentInfo returns card/terminal details of a paid invoice (invoice/payment-info).
+	PaymentInfo(request *Request, opts ...RunOption) (*PaymentInfo, error)
+	// PaymentInfoContext is PaymentInfo with caller-provided context.
+	PaymentInfoContext(ctx context.Context, request *Request, opts ...RunOption) (*PaymentInfo, error)
+
+	// Cancel performs full or partial refund of an invoice (invoice/cancel).
+	Cancel(request *Request, opts ...RunOption) (*CancelResponse, error)
+	// CancelContext is Cancel with caller-provided context.
+	CancelContext(ctx context.Context, request *Request, opts ...RunOption) (*CancelResponse, error)
+
+	// RemoveInvoice invalidates an unpaid invoice (invoice/remove).
+	// Already final invoices produce an error matching ErrInvoiceAlreadyFinal.
+	RemoveInvoice(request *Request, opts ...RunOption) error
+	// RemoveInvoiceContext is RemoveInvoice with caller-provided context.
+	RemoveInvoiceContext(ctx context.Context, request *Request, opts ...RunOption) error
+
+	// Finalize captures a hold, fully or partially (invoice/finalize).
+	Finalize(request *Request, opts ...RunOption) (*FinalizeResponse, error)
+	// FinalizeContext is Finalize with caller-provided context.
+	FinalizeContext(ctx context.Context, request *Request, opts ...RunOption) (*FinalizeResponse, error)
+	// ReleaseHold cancels a hold and releases the held amount (invoice/cancel).
+	ReleaseHold(request *Request, opts ...RunOption) (*CancelResponse, error)
+	// ReleaseHoldContext is ReleaseHold with caller-provided context.
+	ReleaseHoldContext(ctx context.Context, request *Request, opts ...RunOption) (*CancelResponse, error)
+
+	// Statement returns settled operations for a period up to StatementMaxWindow (statement).
+	Statement(request *Request, opts ...RunOption) (*StatementResponse, error)
+	// StatementContext is Statement with caller-provided context.
+	StatementContext(ctx context.Context, request *Request, opts ...RunOption) (*StatementResponse, error)
+	// StatementAll iterates over statement items of an arbitrary long period,
+	// splitting it into API-allowed windows.
+	StatementAll(ctx context.Context, request *Request, opts ...RunOption) iter.Seq2[StatementItem, error]
+
+	// MerchantDetails returns merchant id, name and EDRPOU (details).
+	MerchantDetails(request *Request, opts ...RunOption) (*MerchantDetailsResponse, error)
+	// MerchantDetailsContext is MerchantDetails with caller-provided context.
+	MerchantDetailsContext(ctx context.Context, request *Request, opts ...RunOption) (*MerchantDetailsResponse, error)
+	// SubMerchants lists sub-merchants available under the token (submerchant/list).
+	SubMerchants(request *Request, opts ...RunOption) (*SubMerchantListResponse, error)
+	// SubMerchantsContext is SubMerchants with caller-provided context.
+	SubMerchantsContext(ctx context.Context, request *Request, opts ...RunOption) (*SubMerchantListResponse, error)
+	// Employees lists merchant employees that can receive tips (employee/list).
+	Employees(request *Request, opts ...RunOption) (*EmployeeListResponse, error)
+	// EmployeesContext is Employees with caller-provided context.
+	EmployeesContext(ctx context.Context, request *Request, opts ...RunOption) (*EmployeeListResponse, error)
+
+	// SplitReceivers lists split payment receivers (split-receiver/list).
+	SplitReceivers(request *Request, opts ...RunOption) (*SplitReceiverListResponse, error)
+	// SplitReceiversContext is SplitReceivers with caller-provided context.
+	SplitReceiversContext(ctx context.Context, request *Request, opts ...RunOption) (*SplitReceiverListResponse, error)
+
+	// QRList returns merchant QR cash registers (qr/list).
+	QRList(request *Request, opts ...RunOption) (*QRListResponse, error)
+	// QRListContext is QRList with caller-provided context.
+	QRListContext(ctx context.Context, request *Request, opts ...RunOption) (*QRListResponse, error)
+	// QRDetails returns QR cash register details by qrId (qr/details).
+	QRDetails(request *Request, opts ...RunOption) (*QRDetailsResponse, error)
+	// QRDetailsContext is QRDetails with caller-provided context.
+	QRDetailsContext(ctx context.Context, request *Request, opts ...RunOption) (*QRDetailsResponse, error)
+	// QRResetAmount removes the amount pushed onto a QR (qr/reset-amount).
+	QRResetAmount(request *Request, opts ...RunOption) error
+	// QRResetAmountContext is QRResetAmount with caller-provided context.
+	QRResetAmountContext(ctx context.Context, request *Request, opts ...RunOption) error
 
 	// PublicKey fetches merchant webhook verification public key (pubkey).
 	PublicKey(request *Request, opts ...RunOption) (*PublicKeyResponse, error)
+	// PublicKeyContext is PublicKey with caller-provided context.
+	PublicKeyContext(ctx context.Context, request *Request, opts ...RunOption) (*PublicKeyResponse, error)
 
 	// ParseWebhook parses webhook JSON body.
 	ParseWebhook(body []byte) (*InvoiceStatusResponse, error)
@@ -44,6 +155,13 @@ type Monobank interface {
 	VerifyWebhook(body []byte, xSign string) error
 	// ParseAndVerifyWebhook is a convenience method.
 	ParseAndVerifyWebhook(body []byte, xSign string) (*InvoiceStatusResponse, error)
+	// VerifyWebhookContext is VerifyWebhook with caller-provided context
+	// (used when the public key has to be fetched from the API).
+	VerifyWebhookContext(ctx context.Context, body []byte, xSign string) error
+	// ParseAndVerifyWebhookContext is ParseAndVerifyWebhook with caller-provided context.
+	ParseAndVerifyWebhookContext(ctx context.Context, body []byte, xSign string) (*InvoiceStatusResponse, error)
+	// InvalidateWebhookPublicKey drops cached webhook key; next verification refetches it (pubkey).
+	InvalidateWebhookPublicKey()
 
 	// SetLogLevel changes SDK logging level.
 	SetLogLevel(level log.Level)
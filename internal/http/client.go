@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -11,6 +12,9 @@ import (
 	"time"
 )
 
+// ErrNilResponse is returned by Client.Do when the doer (e.g. a middleware) returns no response and no error.
+var ErrNilResponse = errors.New("http: nil response")
+
 // HTTPDoer is the minimal interface required from an HTTP client.
 // Useful for tests/mocking.
 type HTTPDoer interface {
@@ -62,6 +66,17 @@ func (c *Client) SetClient(hc *http.Client) {
 	c.client = hc
 }
 
+// Wrap decorates underlying doer (e.g. with middleware).
+// It must be called after SetClient, otherwise the wrapped doer is replaced.
+func (c *Client) Wrap(wrap func(HTTPDoer) HTTPDoer) {
+	if wrap == nil {
+		return
+	}
+	if wrapped := wrap(c.client); wrapped != nil {
+		c.client = wrapped
+	}
+}
+
 func (c *Client) Do(req *http.Request) (*http.Response, []byte, error) {
 	if req == nil {
 		return nil, nil, fmt.Errorf("http: request is nil")
@@ -71,6 +86,12 @@ func (c *Client) Do(req *http.Request) (*http.Response, []byte, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	if resp == nil {
+		return nil, nil, ErrNilResponse
+	}
+	if resp.Body == nil {
+		return resp, nil, nil
+	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
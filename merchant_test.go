@@ -0,0 +1,91 @@
+package go_monobank
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newMerchantServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Fatalf("unexpected method: %s", r.Method)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/api/merchant/details":
+					_, _ = w.Write([]byte(`{"merchantId":"12o4Vv7EWy","merchantName":"Shop","edrpou":"4194800"}`))
+				case "/api/merchant/submerchant/list":
+					_, _ = w.Write([]byte(`{"list":[{"code":"0a8637b3bccb42aa93fdeb791b8b58e9","edrpou":"3108011311","iban":"UA213996220000026007233566001"}]}`))
+				case "/api/merchant/employee/list":
+					_, _ = w.Write([]byte(`{"list":[{"id":"3QFX7e7mZfo3R","name":"Employee","extRef":"abra_kadabra"}]}`))
+				default:
+					t.Fatalf("unexpected path: %s", r.URL.Path)
+				}
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMerchantListings(t *testing.T) {
+	t.Parallel()
+
+	server := newMerchantServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	details, err := client.MerchantDetails(nil)
+	if err != nil {
+		t.Fatalf("MerchantDetails() unexpected error: %v", err)
+	}
+	if details.MerchantID != "12o4Vv7EWy" || details.EDRPOU != "4194800" {
+		t.Fatalf("unexpected merchant details: %+v", details)
+	}
+
+	subs, err := client.SubMerchants(nil)
+	if err != nil {
+		t.Fatalf("SubMerchants() unexpected error: %v", err)
+	}
+	if sub, ok := subs.FindByCode("0a8637b3bccb42aa93fdeb791b8b58e9"); !ok || sub.IBAN == "" {
+		t.Fatalf("unexpected sub-merchants: %+v", subs)
+	}
+
+	employees, err := client.Employees(nil)
+	if err != nil {
+		t.Fatalf("Employees() unexpected error: %v", err)
+	}
+	if emp, ok := employees.FindByID("3QFX7e7mZfo3R"); !ok || emp.ExtRef != "abra_kadabra" {
+		t.Fatalf("unexpected employees: %+v", employees)
+	}
+}
+
+func TestRequestValidateMerchantRefs(t *testing.T) {
+	t.Parallel()
+
+	subs := &SubMerchantListResponse{List: []SubMerchant{{Code: "shop-1"}}}
+	employees := &EmployeeListResponse{List: []Employee{{ID: "emp-1"}}}
+
+	valid := NewRequest().WithCode("shop-1").WithTipsEmployeeID("emp-1")
+	if err := valid.ValidateMerchantRefs(subs, employees); err != nil {
+		t.Fatalf("ValidateMerchantRefs() unexpected error: %v", err)
+	}
+
+	for name, request := range map[string]*Request{
+		"unknown code":     NewRequest().WithCode("shop-2"),
+		"unknown employee": NewRequest().WithTipsEmployeeID("emp-2"),
+	} {
+		if err := request.ValidateMerchantRefs(subs, employees); !errors.Is(err, ErrValidation) {
+			t.Fatalf("%s: expected ErrValidation, got %v", name, err)
+		}
+	}
+
+	if err := NewRequest().WithCode("shop-2").ValidateMerchantRefs(nil, nil); err != nil {
+		t.Fatalf("nil lists must skip checks, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package go_monobank
+
+import (
+	"net/http"
+
+	internalhttp "github.com/stremovskyy/go-monobank/internal/http"
+)
+
+// Doer sends a single HTTP request. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+// Middleware wraps every HTTP call made by the SDK (auth header rotation, tracing,
+// request signing, metrics, fault injection, ...).
+//
+// Middleware runs once per attempt, after the SDK has set X-Token, X-Request-ID and
+// other headers, so it may inspect or override them.
+type Middleware func(next Doer) Doer
+
+// chainMiddleware applies mws so that the first one is the outermost.
+func chainMiddleware(mws []Middleware) func(internalhttp.HTTPDoer) internalhttp.HTTPDoer {
+	return func(next internalhttp.HTTPDoer) internalhttp.HTTPDoer {
+		var doer Doer = next
+		for i := len(mws) - 1; i >= 0; i-- {
+			if mws[i] == nil {
+				continue
+			}
+			if wrapped := mws[i](doer); wrapped != nil {
+				doer = wrapped
+			}
+		}
+		return doer
+	}
+}
@@ -0,0 +1,112 @@
+package go_monobank
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareWrapsEveryCallInOrder(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("X-Token"); got != "rotated-token" {
+					t.Fatalf("X-Token = %q, want rotated-token", got)
+				}
+				if got := r.Header.Get("X-Trace"); got != "outer,inner" {
+					t.Fatalf("X-Trace = %q, want outer,inner", got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"invoiceId":"inv-1","status":"success","amount":100,"ccy":980}`))
+			},
+		),
+	)
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+":before")
+				if prev := req.Header.Get("X-Trace"); prev != "" {
+					req.Header.Set("X-Trace", prev+","+name)
+				} else {
+					req.Header.Set("X-Trace", name)
+				}
+				resp, err := next.Do(req)
+				order = append(order, name+":after")
+				return resp, err
+			})
+		}
+	}
+	rotateToken := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Token", "rotated-token")
+			return next.Do(req)
+		})
+	}
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithToken("merchant-token"),
+		WithClient(&http.Client{}),
+		WithMiddleware(trace("outer"), rotateToken, trace("inner")),
+	)
+
+	if _, err := client.Status(NewRequest().WithInvoiceID("inv-1")); err != nil {
+		t.Fatalf("Status() unexpected error: %v", err)
+	}
+
+	want := "outer:before,inner:before,inner:after,outer:after"
+	if got := strings.Join(order, ","); got != want {
+		t.Fatalf("middleware order = %s, want %s", got, want)
+	}
+}
+
+func TestMiddlewareErrorIsReportedAsTransportError(t *testing.T) {
+	t.Parallel()
+
+	chaos := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("chaos: connection reset")
+		})
+	}
+
+	client := NewClient(WithToken("merchant-token"), WithMiddleware(chaos))
+
+	_, err := client.Status(NewRequest().WithInvoiceID("inv-1"))
+	if !errors.Is(err, ErrTransport) {
+		t.Fatalf("expected ErrTransport, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "chaos") {
+		t.Fatalf("expected middleware error cause, got %v", err)
+	}
+}
+
+func TestMiddlewareNilResponse(t *testing.T) {
+	t.Parallel()
+
+	nilResponse := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, nil
+		})
+	}
+	client := NewClient(WithToken("merchant-token"), WithMiddleware(nilResponse))
+	if _, err := client.Status(NewRequest().WithInvoiceID("inv-1")); !errors.Is(err, ErrUnexpectedResponse) {
+		t.Fatalf("expected ErrUnexpectedResponse for nil response, got %v", err)
+	}
+
+	nilBody := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusNoContent}, nil
+		})
+	}
+	client = NewClient(WithToken("merchant-token"), WithMiddleware(nilBody))
+	if err := client.DeleteWalletCard(NewRequest().WithCardToken("tok_1")); err != nil {
+		t.Fatalf("nil body must be treated as empty, got %v", err)
+	}
+}
@@ -0,0 +1,378 @@
+package monobanktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	go_monobank "github.com/stremovskyy/go-monobank"
+	"github.com/stremovskyy/go-monobank/consts"
+)
+
+const (
+	pathPay = "/pay/"
+	path3DS = "/3ds/"
+)
+
+type invoiceCreateRequest struct {
+	Amount           int64                         `json:"amount"`
+	Currency         go_monobank.CurrencyCode      `json:"ccy"`
+	MerchantPaymInfo *go_monobank.MerchantPaymInfo `json:"merchantPaymInfo"`
+	WebHookURL       *string                       `json:"webHookUrl"`
+	PaymentType      go_monobank.PaymentType       `json:"paymentType"`
+	SaveCardData     *go_monobank.SaveCardData     `json:"saveCardData"`
+}
+
+type walletPaymentRequest struct {
+	CardToken        string                        `json:"cardToken"`
+	Amount           int64                         `json:"amount"`
+	Currency         go_monobank.CurrencyCode      `json:"ccy"`
+	MerchantPaymInfo *go_monobank.MerchantPaymInfo `json:"merchantPaymInfo"`
+	WebHookURL       *string                       `json:"webHookUrl"`
+	PaymentType      go_monobank.PaymentType       `json:"paymentType"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.wait(r) {
+		return
+	}
+
+	// Browser-facing pages returned in pageUrl/tdsUrl do not need X-Token.
+	switch {
+	case strings.HasPrefix(r.URL.Path, pathPay):
+		s.handlePayPage(w, strings.TrimPrefix(r.URL.Path, pathPay))
+		return
+	case strings.HasPrefix(r.URL.Path, path3DS):
+		s.handle3DSPage(w, strings.TrimPrefix(r.URL.Path, path3DS))
+		return
+	}
+
+	if e, ok := s.scriptedError(r.URL.Path); ok {
+		writeScriptedError(w, e)
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "forbidden")
+		return
+	}
+
+	switch r.URL.Path {
+	case consts.PathInvoiceCreate:
+		s.route(w, r, http.MethodPost, s.handleInvoiceCreate)
+	case consts.PathInvoiceStatus:
+		s.route(w, r, http.MethodGet, s.handleInvoiceStatus)
+	case consts.PathWallet:
+		s.route(w, r, http.MethodGet, s.handleWallet)
+	case consts.PathWalletPayment:
+		s.route(w, r, http.MethodPost, s.handleWalletPayment)
+	case consts.PathInvoiceFiscalChecks:
+		s.route(w, r, http.MethodGet, s.handleFiscalChecks)
+	case consts.PathPubKey:
+		s.route(w, r, http.MethodGet, s.handlePubKey)
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "unknown endpoint "+r.URL.Path)
+	}
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, method string, fn http.HandlerFunc) {
+	if r.Method != method {
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+	fn(w, r)
+}
+
+// wait applies configured latency; it returns false when the client went away.
+func (s *Server) wait(r *http.Request) bool {
+	s.mu.Lock()
+	d := s.latency
+	s.mu.Unlock()
+	if d <= 0 {
+		return true
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func (s *Server) scriptedError(path string) (ScriptedError, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	queue := s.failures[path]
+	if len(queue) == 0 {
+		return ScriptedError{}, false
+	}
+	s.failures[path] = queue[1:]
+	return queue[0], true
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	got := strings.TrimSpace(r.Header.Get("X-Token"))
+	s.mu.Lock()
+	want := s.token
+	s.mu.Unlock()
+	if want == "" {
+		return got != ""
+	}
+	return got == want
+}
+
+func (s *Server) handleInvoiceCreate(w http.ResponseWriter, r *http.Request) {
+	var req invoiceCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	saveCard := req.SaveCardData != nil && req.SaveCardData.SaveCard
+	switch {
+	case req.PaymentType == go_monobank.PaymentTypeVerification && req.Amount != 0:
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "amount must be 0 for verification")
+		return
+	case req.PaymentType == go_monobank.PaymentTypeVerification && !saveCard:
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "saveCardData.saveCard is required for verification")
+		return
+	case req.PaymentType != go_monobank.PaymentTypeVerification && req.Amount <= 0:
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "amount must be positive")
+		return
+	}
+
+	s.mu.Lock()
+	inv := s.newInvoice(req.Amount, req.Currency, req.MerchantPaymInfo, req.WebHookURL)
+	inv.paymentType = req.PaymentType
+	if saveCard {
+		inv.walletID = req.SaveCardData.WalletID
+	}
+	resp := go_monobank.InvoiceCreateResponse{
+		InvoiceID: inv.state.InvoiceID,
+		PageURL:   s.URL() + pathPay + inv.state.InvoiceID,
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleInvoiceStatus(w http.ResponseWriter, r *http.Request) {
+	state, ok := s.Invoice(r.URL.Query().Get("invoiceId"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "invoice not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+func (s *Server) handleWallet(w http.ResponseWriter, r *http.Request) {
+	walletID := r.URL.Query().Get("walletId")
+	s.mu.Lock()
+	items := append([]go_monobank.WalletItem{}, s.wallets[walletID]...)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, go_monobank.WalletResponse{Wallet: items})
+}
+
+func (s *Server) handleWalletPayment(w http.ResponseWriter, r *http.Request) {
+	var req walletPaymentRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Amount <= 0 {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "amount must be positive")
+		return
+	}
+
+	s.mu.Lock()
+	if !s.hasCard(req.CardToken) {
+		s.mu.Unlock()
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "card token not found")
+		return
+	}
+	inv := s.newInvoice(req.Amount, req.Currency, req.MerchantPaymInfo, req.WebHookURL)
+	inv.paymentType = req.PaymentType
+	inv.outcome = s.defaultOutcome
+	if o, ok := s.outcomes[req.CardToken]; ok {
+		inv.outcome = o
+	}
+
+	events := []go_monobank.InvoiceStatusResponse{s.transition(inv, go_monobank.InvoiceProcessing)}
+	resp := go_monobank.WalletPaymentResponse{InvoiceID: inv.state.InvoiceID}
+	if inv.outcome.Require3DS {
+		tdsURL := s.URL() + path3DS + inv.state.InvoiceID
+		resp.TDSURL = &tdsURL
+	} else {
+		events = append(events, s.finish(inv))
+	}
+	resp.Status = inv.state.Status
+	resp.FailureReason = inv.state.FailureReason
+	resp.Amount = inv.state.Amount
+	resp.Currency = inv.state.Currency
+	resp.CreatedDate = inv.state.CreatedDate
+	resp.ModifiedDate = inv.state.ModifiedDate
+	sent := make(chan struct{})
+	s.enqueue(sent, inv.webhookURL, events...)
+	s.mu.Unlock()
+
+	// Webhooks follow the response, as in production: the client learns
+	// invoiceId first and a slow webhook receiver does not stall the call.
+	writeJSON(w, http.StatusOK, resp)
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+	close(sent)
+}
+
+func (s *Server) handleFiscalChecks(w http.ResponseWriter, r *http.Request) {
+	state, ok := s.Invoice(r.URL.Query().Get("invoiceId"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "invoice not found")
+		return
+	}
+
+	resp := go_monobank.FiscalChecksResponse{Checks: []go_monobank.FiscalCheck{}}
+	if state.Status == go_monobank.InvoiceSuccess {
+		resp.Checks = append(resp.Checks, go_monobank.FiscalCheck{
+			ID:                  "check-" + state.InvoiceID,
+			Type:                "sale",
+			Status:              "done",
+			TaxURL:              s.URL() + "/tax/" + state.InvoiceID,
+			FiscalizationSource: "monopay",
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handlePubKey(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, go_monobank.PublicKeyResponse{Key: s.PublicKeyBase64()})
+}
+
+// handlePayPage pays invoice with default outcome, as if customer opened pageUrl.
+func (s *Server) handlePayPage(w http.ResponseWriter, invoiceID string) {
+	s.mu.Lock()
+	o := s.defaultOutcome
+	s.mu.Unlock()
+
+	if err := s.Pay(invoiceID, o); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handle3DSPage completes 3-D Secure, as if customer passed the challenge at tdsUrl.
+func (s *Server) handle3DSPage(w http.ResponseWriter, invoiceID string) {
+	if err := s.Complete3DS(invoiceID); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// hasCard must be called with mu held.
+func (s *Server) hasCard(cardToken string) bool {
+	for _, items := range s.wallets {
+		for _, item := range items {
+			if item.CardToken == cardToken {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// enqueue must be called with mu held. It posts webhooks in background once ready
+// is closed (nil means at once) and webhooks queued earlier are sent, so receivers
+// see status changes in order. The returned channel is closed after delivery.
+func (s *Server) enqueue(ready <-chan struct{}, url string, events ...go_monobank.InvoiceStatusResponse) <-chan struct{} {
+	done := make(chan struct{})
+	prev := s.lastDelivery
+	s.lastDelivery = done
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		defer close(done)
+		if ready != nil {
+			<-ready
+		}
+		if prev != nil {
+			<-prev
+		}
+		s.deliver(url, events...)
+	}()
+	return done
+}
+
+// deliver posts signed webhooks one by one and records deliveries.
+func (s *Server) deliver(url string, events ...go_monobank.InvoiceStatusResponse) {
+	if url == "" {
+		return
+	}
+	for _, event := range events {
+		d := WebhookDelivery{URL: url}
+		d.Body, d.XSign, d.Err = s.signer.SignEvent(&event)
+		if d.Err == nil {
+			d.StatusCode, d.Err = s.post(url, d.Body, d.XSign)
+		}
+
+		s.mu.Lock()
+		s.deliveries = append(s.deliveries, d)
+		s.mu.Unlock()
+	}
+}
+
+func (s *Server) post(url string, body []byte, xSign string) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sign", xSign)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeScriptedError(w http.ResponseWriter, e ScriptedError) {
+	status := e.StatusCode
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	if e.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds()))))
+	}
+	errText := e.ErrText
+	if errText == "" {
+		errText = http.StatusText(status)
+	}
+	writeError(w, status, e.ErrCode, errText)
+}
+
+func writeError(w http.ResponseWriter, status int, errCode, errText string) {
+	writeJSON(w, status, map[string]string{"errCode": errCode, "errText": errText})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,351 @@
+// Package monobanktest provides an in-process fake of monobank acquiring API for tests.
+//
+// The fake is stateful: invoices move through created → processing → success/failure
+// (or hold), saved cards appear in wallets, and every status change is delivered as
+// an ECDSA-signed webhook that go_monobank verifies with the server public key.
+//
+//	srv := monobanktest.NewServer(monobanktest.WithWebhookURL(webhooks.URL))
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	resp, err := client.Payment(request)
+package monobanktest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	go_monobank "github.com/stremovskyy/go-monobank"
+)
+
+// Outcome scripts how a payment ends.
+type Outcome struct {
+	// Status is the final status: success (default) or failure.
+	// Payments with paymentType=hold end in hold instead of success.
+	Status go_monobank.InvoiceStatus
+	// ErrCode and FailureReason are reported for failed payments.
+	ErrCode       string
+	FailureReason string
+	// Require3DS keeps payment in processing and returns tdsUrl until Complete3DS is called.
+	Require3DS bool
+}
+
+// ScriptedError is returned instead of a regular response (see FailNext).
+type ScriptedError struct {
+	StatusCode int
+	ErrCode    string
+	ErrText    string
+	// RetryAfter is sent as Retry-After header (seconds) when > 0.
+	RetryAfter time.Duration
+}
+
+// WebhookDelivery is a webhook sent by the server.
+type WebhookDelivery struct {
+	URL        string
+	Body       []byte
+	XSign      string
+	StatusCode int
+	Err        error
+}
+
+// Option configures Server.
+type Option func(*Server)
+
+// WithToken makes server accept only this X-Token (any non-empty token is accepted by default).
+func WithToken(token string) Option {
+	return func(s *Server) { s.token = strings.TrimSpace(token) }
+}
+
+// WithWebhookURL sets webhook URL for invoices created without webHookUrl.
+func WithWebhookURL(url string) Option {
+	return func(s *Server) { s.webhookURL = strings.TrimSpace(url) }
+}
+
+// WithLatency delays every API response.
+func WithLatency(d time.Duration) Option {
+	return func(s *Server) { s.latency = d }
+}
+
+// WithDefaultOutcome sets outcome for payments without a card-specific outcome.
+func WithDefaultOutcome(o Outcome) Option {
+	return func(s *Server) { s.defaultOutcome = o }
+}
+
+// WithSigner sets signer used for webhooks (a new P-256 key is generated by default).
+func WithSigner(signer *go_monobank.WebhookSigner) Option {
+	return func(s *Server) {
+		if signer != nil {
+			s.signer = signer
+		}
+	}
+}
+
+// Server is a fake monobank acquiring API backed by httptest.Server.
+type Server struct {
+	srv        *httptest.Server
+	httpClient *http.Client
+
+	mu             sync.Mutex
+	token          string
+	webhookURL     string
+	latency        time.Duration
+	signer         *go_monobank.WebhookSigner
+	defaultOutcome Outcome
+	outcomes       map[string]Outcome
+	failures       map[string][]ScriptedError
+	invoices       map[string]*invoice
+	wallets        map[string][]go_monobank.WalletItem
+	deliveries     []WebhookDelivery
+	lastDelivery   <-chan struct{}
+	pending        sync.WaitGroup
+	seq            int
+}
+
+type invoice struct {
+	state       go_monobank.InvoiceStatusResponse
+	webhookURL  string
+	paymentType go_monobank.PaymentType
+	walletID    string
+	outcome     Outcome
+}
+
+// NewServer starts a fake server. Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		outcomes:   make(map[string]Outcome),
+		failures:   make(map[string][]ScriptedError),
+		invoices:   make(map[string]*invoice),
+		wallets:    make(map[string][]go_monobank.WalletItem),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	if s.signer == nil {
+		signer, err := go_monobank.NewWebhookSigner()
+		if err != nil {
+			panic(fmt.Sprintf("monobanktest: %v", err))
+		}
+		s.signer = signer
+	}
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns server base URL (use with go_monobank.WithBaseURL).
+func (s *Server) URL() string { return s.srv.URL }
+
+// Close shuts the server down and waits for webhooks in flight.
+func (s *Server) Close() {
+	s.srv.Close()
+	s.pending.Wait()
+}
+
+// PublicKeyBase64 returns webhook public key in /api/merchant/pubkey format.
+func (s *Server) PublicKeyBase64() string { return s.signer.PublicKeyBase64() }
+
+// Signer returns signer used for webhooks, e.g. to sign events a test sends on its own.
+func (s *Server) Signer() *go_monobank.WebhookSigner { return s.signer }
+
+// Client returns go_monobank client configured for this server.
+func (s *Server) Client(opts ...go_monobank.Option) go_monobank.Monobank {
+	s.mu.Lock()
+	token := s.token
+	s.mu.Unlock()
+	if token == "" {
+		token = "monobanktest-token"
+	}
+	base := []go_monobank.Option{
+		go_monobank.WithBaseURL(s.URL()),
+		go_monobank.WithToken(token),
+		go_monobank.WithWebhookPublicKeyBase64(s.PublicKeyBase64()),
+	}
+	return go_monobank.NewClient(append(base, opts...)...)
+}
+
+// SetLatency changes response delay.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// SetOutcome scripts outcome of payments by cardToken.
+func (s *Server) SetOutcome(cardToken string, o Outcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outcomes[cardToken] = o
+}
+
+// FailNext makes the next request to path (e.g. consts.PathWalletPayment) fail with e.
+// Calls queue up: each scripted error is used once.
+func (s *Server) FailNext(path string, e ScriptedError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[path] = append(s.failures[path], e)
+}
+
+// AddCard puts a tokenized card into wallet.
+func (s *Server) AddCard(walletID, cardToken, maskedPan string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wallets[walletID] = append(s.wallets[walletID], go_monobank.WalletItem{CardToken: cardToken, MaskedPan: maskedPan, Country: "804"})
+}
+
+// Invoice returns current state of invoice.
+func (s *Server) Invoice(invoiceID string) (go_monobank.InvoiceStatusResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inv, ok := s.invoices[invoiceID]
+	if !ok {
+		return go_monobank.InvoiceStatusResponse{}, false
+	}
+	return inv.state, true
+}
+
+// Webhooks returns webhooks sent so far. Webhooks of wallet/payment are sent in
+// background after the response; call WaitWebhooks first to include them.
+func (s *Server) Webhooks() []WebhookDelivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]WebhookDelivery, len(s.deliveries))
+	copy(out, s.deliveries)
+	return out
+}
+
+// WaitWebhooks blocks until all queued webhooks are delivered.
+func (s *Server) WaitWebhooks() { s.pending.Wait() }
+
+// Pay simulates customer paying a hosted-checkout invoice (invoice/create):
+// created → processing → outcome. Saved card is added to wallet on success.
+func (s *Server) Pay(invoiceID string, o Outcome) error {
+	s.mu.Lock()
+	inv, ok := s.invoices[invoiceID]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("monobanktest: invoice %s not found", invoiceID)
+	}
+	if inv.state.Status != go_monobank.InvoiceCreated {
+		s.mu.Unlock()
+		return fmt.Errorf("monobanktest: invoice %s is %s, want created", invoiceID, inv.state.Status)
+	}
+	inv.outcome = o
+	events := []go_monobank.InvoiceStatusResponse{s.transition(inv, go_monobank.InvoiceProcessing)}
+	if !o.Require3DS {
+		events = append(events, s.finish(inv))
+	}
+	done := s.enqueue(nil, inv.webhookURL, events...)
+	s.mu.Unlock()
+
+	<-done
+	return nil
+}
+
+// Complete3DS finishes a payment waiting for 3-D Secure with its scripted outcome.
+func (s *Server) Complete3DS(invoiceID string) error {
+	s.mu.Lock()
+	inv, ok := s.invoices[invoiceID]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("monobanktest: invoice %s not found", invoiceID)
+	}
+	if inv.state.Status != go_monobank.InvoiceProcessing {
+		s.mu.Unlock()
+		return fmt.Errorf("monobanktest: invoice %s is %s, want processing", invoiceID, inv.state.Status)
+	}
+	event := s.finish(inv)
+	done := s.enqueue(nil, inv.webhookURL, event)
+	s.mu.Unlock()
+
+	<-done
+	return nil
+}
+
+// transition must be called with mu held.
+func (s *Server) transition(inv *invoice, status go_monobank.InvoiceStatus) go_monobank.InvoiceStatusResponse {
+	now := time.Now().UTC()
+	if !now.After(inv.state.ModifiedDate) {
+		now = inv.state.ModifiedDate.Add(time.Millisecond)
+	}
+	inv.state.Status = status
+	inv.state.ModifiedDate = now
+	return inv.state
+}
+
+// finish applies scripted outcome; must be called with mu held.
+func (s *Server) finish(inv *invoice) go_monobank.InvoiceStatusResponse {
+	o := inv.outcome
+	if o.Status == go_monobank.InvoiceFailure {
+		if o.ErrCode != "" {
+			code := o.ErrCode
+			inv.state.ErrCode = &code
+		}
+		reason := o.FailureReason
+		if reason == "" {
+			reason = "payment declined"
+		}
+		inv.state.FailureReason = &reason
+		return s.transition(inv, go_monobank.InvoiceFailure)
+	}
+
+	final := inv.state.Amount
+	inv.state.FinalAmount = &final
+	masked := "444403******1902"
+	inv.state.PaymentInfo = &go_monobank.PaymentInfo{MaskedPan: &masked}
+	if inv.walletID != "" {
+		s.seq++
+		token := fmt.Sprintf("card-token-%d", s.seq)
+		s.wallets[inv.walletID] = append(s.wallets[inv.walletID], go_monobank.WalletItem{CardToken: token, MaskedPan: masked, Country: "804"})
+		inv.state.WalletData = &go_monobank.WalletData{CardToken: token, WalletID: inv.walletID, Status: "new"}
+	}
+	switch inv.paymentType {
+	case go_monobank.PaymentTypeHold:
+		return s.transition(inv, go_monobank.InvoiceHold)
+	case go_monobank.PaymentTypeVerification:
+		// Verification only tokenizes the card: nothing is charged.
+		inv.state.FinalAmount = new(int64)
+	}
+	return s.transition(inv, go_monobank.InvoiceSuccess)
+}
+
+// newInvoice must be called with mu held.
+func (s *Server) newInvoice(amount int64, ccy go_monobank.CurrencyCode, info *go_monobank.MerchantPaymInfo, webhookURL *string) *invoice {
+	s.seq++
+	if ccy == 0 {
+		ccy = go_monobank.CurrencyUAH
+	}
+	now := time.Now().UTC()
+	inv := &invoice{
+		state: go_monobank.InvoiceStatusResponse{
+			InvoiceID:    fmt.Sprintf("test-invoice-%d", s.seq),
+			Status:       go_monobank.InvoiceCreated,
+			Amount:       amount,
+			Currency:     ccy,
+			CreatedDate:  now,
+			ModifiedDate: now,
+		},
+		webhookURL: s.webhookURL,
+	}
+	if webhookURL != nil && strings.TrimSpace(*webhookURL) != "" {
+		inv.webhookURL = strings.TrimSpace(*webhookURL)
+	}
+	if info != nil {
+		if info.Reference != "" {
+			ref := info.Reference
+			inv.state.Reference = &ref
+		}
+		if info.Destination != "" {
+			dest := info.Destination
+			inv.state.Destination = &dest
+		}
+	}
+	s.invoices[inv.state.InvoiceID] = inv
+	return inv
+}
@@ -0,0 +1,302 @@
+package monobanktest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	go_monobank "github.com/stremovskyy/go-monobank"
+	"github.com/stremovskyy/go-monobank/consts"
+)
+
+type webhookSink struct {
+	srv *httptest.Server
+
+	mu     sync.Mutex
+	events []*go_monobank.InvoiceStatusResponse
+	errs   []error
+}
+
+func newWebhookSink(t *testing.T, client func() go_monobank.Monobank) *webhookSink {
+	t.Helper()
+
+	sink := &webhookSink{}
+	sink.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		event, err := client().ParseAndVerifyWebhook(body, r.Header.Get("X-Sign"))
+
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		if err != nil {
+			sink.errs = append(sink.errs, err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		sink.events = append(sink.events, event)
+	}))
+	t.Cleanup(sink.srv.Close)
+	return sink
+}
+
+func (s *webhookSink) statuses() []go_monobank.InvoiceStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]go_monobank.InvoiceStatus, 0, len(s.events))
+	for _, event := range s.events {
+		out = append(out, event.Status)
+	}
+	return out
+}
+
+func TestServerWalletPaymentLifecycle(t *testing.T) {
+	t.Parallel()
+
+	var srv *Server
+	sink := newWebhookSink(t, func() go_monobank.Monobank { return srv.Client() })
+	srv = NewServer(WithWebhookURL(sink.srv.URL))
+	defer srv.Close()
+
+	srv.AddCard("wallet-1", "card-ok", "444403******1902")
+	srv.AddCard("wallet-1", "card-declined", "537541******1234")
+	srv.SetOutcome("card-declined", Outcome{Status: go_monobank.InvoiceFailure, ErrCode: "59", FailureReason: "insufficient funds"})
+
+	client := srv.Client()
+
+	wallet, err := client.Wallet(go_monobank.NewRequest().WithWalletID("wallet-1"))
+	if err != nil || len(wallet.Wallet) != 2 {
+		t.Fatalf("Wallet() = %+v, %v", wallet, err)
+	}
+
+	ok, err := client.Payment(go_monobank.NewRequest().WithCardToken("card-ok").WithAmount(1000).WithInitiationKind(go_monobank.InitiationMerchant))
+	if err != nil {
+		t.Fatalf("Payment() error: %v", err)
+	}
+	if !ok.IsSuccess() {
+		t.Fatalf("expected success, got %s", ok.Status)
+	}
+
+	declined, err := client.Payment(go_monobank.NewRequest().WithCardToken("card-declined").WithAmount(1000).WithInitiationKind(go_monobank.InitiationMerchant))
+	if err != nil {
+		t.Fatalf("Payment() error: %v", err)
+	}
+	if declined.Status != go_monobank.InvoiceFailure {
+		t.Fatalf("expected failure, got %s", declined.Status)
+	}
+
+	status, err := client.Status(go_monobank.NewRequest().WithInvoiceID(declined.InvoiceID))
+	if err != nil {
+		t.Fatalf("Status() error: %v", err)
+	}
+	if paymentErr := status.PaymentError(); paymentErr == nil || paymentErr.ErrCode != "59" {
+		t.Fatalf("unexpected payment error: %+v", paymentErr)
+	}
+
+	checks, err := client.FiscalChecks(go_monobank.NewRequest().WithInvoiceID(ok.InvoiceID))
+	if err != nil || !checks.HasChecks() {
+		t.Fatalf("FiscalChecks() = %+v, %v", checks, err)
+	}
+
+	srv.WaitWebhooks()
+	want := []go_monobank.InvoiceStatus{
+		go_monobank.InvoiceProcessing, go_monobank.InvoiceSuccess,
+		go_monobank.InvoiceProcessing, go_monobank.InvoiceFailure,
+	}
+	got := sink.statuses()
+	if len(got) != len(want) {
+		t.Fatalf("webhooks = %v, want %v (errors: %v)", got, want, sink.errs)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("webhooks = %v, want %v", got, want)
+		}
+	}
+	for _, d := range srv.Webhooks() {
+		if d.Err != nil || d.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected delivery: %+v", d)
+		}
+	}
+}
+
+func TestServerRespondsBeforeWebhooks(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	received := make(chan struct{}, 2)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		<-release
+	}))
+	defer receiver.Close()
+
+	srv := NewServer(WithWebhookURL(receiver.URL))
+	defer srv.Close()
+	srv.AddCard("wallet-1", "card-ok", "444403******1902")
+
+	payment, err := srv.Client().Payment(go_monobank.NewRequest().WithCardToken("card-ok").WithAmount(1000).WithInitiationKind(go_monobank.InitiationMerchant))
+	if err != nil {
+		t.Fatalf("Payment() error: %v", err)
+	}
+	if payment.InvoiceID == "" {
+		t.Fatalf("expected invoiceId, got %+v", payment)
+	}
+
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatalf("webhook was not delivered after the response")
+	}
+	if got := len(srv.Webhooks()); got != 0 {
+		t.Fatalf("webhooks recorded while receiver is blocked: %d", got)
+	}
+
+	close(release)
+	srv.WaitWebhooks()
+	if got := len(srv.Webhooks()); got != 2 {
+		t.Fatalf("webhooks = %d, want 2", got)
+	}
+}
+
+func TestServerVerificationTokenizesCard(t *testing.T) {
+	t.Parallel()
+
+	var srv *Server
+	sink := newWebhookSink(t, func() go_monobank.Monobank { return srv.Client() })
+	srv = NewServer(WithWebhookURL(sink.srv.URL))
+	defer srv.Close()
+	client := srv.Client()
+
+	created, err := client.Verification(go_monobank.NewRequest().WithPaymentType(go_monobank.PaymentTypeVerification).SaveCard("wallet-1"))
+	if err != nil {
+		t.Fatalf("Verification() error: %v", err)
+	}
+	if err := srv.Pay(created.InvoiceID, Outcome{}); err != nil {
+		t.Fatalf("Pay() error: %v", err)
+	}
+
+	status, err := client.Status(go_monobank.NewRequest().WithInvoiceID(created.InvoiceID))
+	if err != nil {
+		t.Fatalf("Status() error: %v", err)
+	}
+	if !status.IsSuccess() || status.WalletData == nil || status.FinalAmount == nil || *status.FinalAmount != 0 {
+		t.Fatalf("expected success with walletData and zero finalAmount, got %+v", status)
+	}
+
+	wallet, err := client.Wallet(go_monobank.NewRequest().WithWalletID("wallet-1"))
+	if err != nil || len(wallet.Wallet) != 1 || wallet.Wallet[0].CardToken != status.WalletData.CardToken {
+		t.Fatalf("Wallet() = %+v, %v", wallet, err)
+	}
+
+	want := []go_monobank.InvoiceStatus{go_monobank.InvoiceProcessing, go_monobank.InvoiceSuccess}
+	if got := sink.statuses(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("webhooks = %v, want %v (errors: %v)", got, want, sink.errs)
+	}
+}
+
+func TestServerRejectsInvalidVerification(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "verification with amount", body: `{"amount":100,"paymentType":"verification","saveCardData":{"saveCard":true,"walletId":"wallet-1"}}`},
+		{name: "verification without saveCard", body: `{"amount":0,"paymentType":"verification"}`},
+		{name: "debit without amount", body: `{"amount":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, srv.URL()+consts.PathInvoiceCreate, strings.NewReader(tt.body))
+			req.Header.Set("X-Token", "token")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("invoice/create: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServerInvoiceSaveCardAnd3DS(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	created, err := client.CreateInvoice(go_monobank.NewRequest().WithAmount(500).WithWalletID("wallet-1").EnableSaveCard())
+	if err != nil {
+		t.Fatalf("CreateInvoice() error: %v", err)
+	}
+	if err := srv.Pay(created.InvoiceID, Outcome{}); err != nil {
+		t.Fatalf("Pay() error: %v", err)
+	}
+
+	status, err := client.Status(go_monobank.NewRequest().WithInvoiceID(created.InvoiceID))
+	if err != nil {
+		t.Fatalf("Status() error: %v", err)
+	}
+	if !status.IsSuccess() || status.WalletData == nil {
+		t.Fatalf("expected success with walletData, got %+v", status)
+	}
+
+	token := status.WalletData.CardToken
+	srv.SetOutcome(token, Outcome{Require3DS: true})
+	payment, err := client.Payment(go_monobank.NewRequest().WithCardToken(token).WithAmount(500).WithInitiationKind(go_monobank.InitiationMerchant))
+	if err != nil {
+		t.Fatalf("Payment() error: %v", err)
+	}
+	if !payment.Requires3DS() || payment.Status != go_monobank.InvoiceProcessing {
+		t.Fatalf("expected 3DS challenge, got %+v", payment)
+	}
+
+	resp, err := http.Get(*payment.TDSURL)
+	if err != nil {
+		t.Fatalf("open tdsUrl: %v", err)
+	}
+	resp.Body.Close()
+
+	if state, _ := srv.Invoice(payment.InvoiceID); state.Status != go_monobank.InvoiceSuccess {
+		t.Fatalf("expected success after 3DS, got %s", state.Status)
+	}
+}
+
+func TestServerScriptedErrorsAndLatency(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer(WithToken("secret"))
+	defer srv.Close()
+
+	srv.FailNext(consts.PathPubKey, ScriptedError{StatusCode: http.StatusTooManyRequests, ErrCode: "TMR", RetryAfter: 2 * time.Second})
+
+	client := srv.Client()
+	_, err := client.PublicKey(nil)
+	var apiErr *go_monobank.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.RetryAfter == nil || *apiErr.RetryAfter != 2*time.Second {
+		t.Fatalf("expected scripted 429, got %v", err)
+	}
+
+	key, err := client.PublicKey(nil)
+	if err != nil || key.Key != srv.PublicKeyBase64() {
+		t.Fatalf("PublicKey() = %+v, %v", key, err)
+	}
+
+	if _, err := srv.Client(go_monobank.WithToken("wrong")).PublicKey(nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for wrong token, got %v", err)
+	}
+
+	srv.SetLatency(200 * time.Millisecond)
+	if _, err := srv.Client(go_monobank.WithTimeout(20 * time.Millisecond)).PublicKey(nil); err == nil {
+		t.Fatalf("expected timeout with latency")
+	}
+}
@@ -16,6 +16,11 @@ type clientConfig struct {
 	httpOptions *internalhttp.Options
 	httpClient  *http.Client
 	recorder    recorder.Recorder
+	retry       *RetryPolicy
+	middleware  []Middleware
+	rateLimiter *rateLimiter
+	breaker     *circuitBreaker
+	redactor    *redactor
 
 	// defaultToken is used when request.Merchant.Token is empty.
 	defaultToken string
@@ -25,12 +30,22 @@ type clientConfig struct {
 
 	// webhookPublicKeyPEM is raw PEM (decoded).
 	webhookPublicKeyPEM []byte
+
+	// webhookKeyRefetchInterval limits how often pubkey is refetched after signature failures.
+	webhookKeyRefetchInterval time.Duration
+	// webhookKeyGracePeriod keeps replaced key valid after rotation.
+	webhookKeyGracePeriod time.Duration
+	onWebhookKeyChange    func(previousKey, currentKey string)
 }
 
 func defaultClientConfig() *clientConfig {
 	return &clientConfig{
 		baseURL:     consts.DefaultBaseURL,
 		httpOptions: internalhttp.DefaultOptions(),
+		redactor:    newRedactor(RedactionConfig{}),
+
+		webhookKeyRefetchInterval: defaultWebhookKeyRefetchInterval,
+		webhookKeyGracePeriod:     defaultWebhookKeyGracePeriod,
 	}
 }
 
@@ -81,6 +96,43 @@ func WithClient(cl *http.Client) Option {
 	}
 }
 
+// WithMiddleware registers HTTP middleware around every SDK call.
+// Middleware registered first is the outermost; it also wraps a client set by WithClient.
+func WithMiddleware(mws ...Middleware) Option {
+	return func(c *clientConfig) {
+		for _, mw := range mws {
+			if mw != nil {
+				c.middleware = append(c.middleware, mw)
+			}
+		}
+	}
+}
+
+// WithRateLimiter enables client-side token-bucket throttling keyed by resolved X-Token.
+// Requests wait for their turn unless the wait exceeds ctx deadline (then ErrClientRateLimited).
+// Buckets are paused automatically when API responds with Retry-After.
+func WithRateLimiter(cfg RateLimiterConfig) Option {
+	return func(c *clientConfig) {
+		c.rateLimiter = newRateLimiter(cfg)
+	}
+}
+
+// WithCircuitBreaker enables per-endpoint circuit breaker: after consecutive
+// ErrTransport/ErrServerError failures calls fail immediately with ErrCircuitOpen.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) Option {
+	return func(c *clientConfig) {
+		c.breaker = newCircuitBreaker(cfg)
+	}
+}
+
+// WithRedaction configures masking of tokens, card numbers and emails in debug logs,
+// recorder payloads, default DryRun output and APIError/DecodeError bodies.
+func WithRedaction(cfg RedactionConfig) Option {
+	return func(c *clientConfig) {
+		c.redactor = newRedactor(cfg)
+	}
+}
+
 // WithRecorder attaches request/response recorder.
 func WithRecorder(rec recorder.Recorder) Option {
 	return func(c *clientConfig) {
@@ -88,6 +140,15 @@ func WithRecorder(rec recorder.Recorder) Option {
 	}
 }
 
+// WithRetryPolicy enables automatic retries of transport errors, HTTP 429 and 5xx.
+// Zero fields of policy are filled from DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *clientConfig) {
+		normalized := policy.normalized()
+		c.retry = &normalized
+	}
+}
+
 // WithToken sets default X-Token.
 // If request.Merchant.Token is empty, client will use this token.
 func WithToken(token string) Option {
@@ -113,6 +174,33 @@ func WithWebhookPublicKeyPEM(pemBytes []byte) Option {
 	}
 }
 
+// WithWebhookKeyRefetchInterval limits how often webhook public key is refetched
+// from /api/merchant/pubkey after signature failures (default 1 minute).
+func WithWebhookKeyRefetchInterval(d time.Duration) Option {
+	return func(c *clientConfig) {
+		if d >= 0 {
+			c.webhookKeyRefetchInterval = d
+		}
+	}
+}
+
+// WithWebhookKeyGracePeriod sets how long a rotated-out webhook key is still accepted (default 1 hour).
+func WithWebhookKeyGracePeriod(d time.Duration) Option {
+	return func(c *clientConfig) {
+		if d >= 0 {
+			c.webhookKeyGracePeriod = d
+		}
+	}
+}
+
+// WithWebhookKeyChangeHandler registers callback invoked when webhook public key changes.
+// Keys are base64-encoded PEM, same format as PublicKeyResponse.Key.
+func WithWebhookKeyChangeHandler(fn func(previousKey, currentKey string)) Option {
+	return func(c *clientConfig) {
+		c.onWebhookKeyChange = fn
+	}
+}
+
 // NewClient creates Monobank client with custom options.
 func NewClient(opts ...Option) Monobank {
 	cfg := defaultClientConfig()
@@ -126,6 +214,9 @@ func NewClient(opts ...Option) Monobank {
 	if cfg.httpClient != nil {
 		hc.SetClient(cfg.httpClient)
 	}
+	if len(cfg.middleware) > 0 {
+		hc.Wrap(chainMiddleware(cfg.middleware))
+	}
 
 	return &client{
 		http: hc,
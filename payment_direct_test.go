@@ -0,0 +1,189 @@
+package go_monobank
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stremovskyy/go-monobank/consts"
+	"github.com/stremovskyy/recorder"
+)
+
+const (
+	testCardPAN = "4242424242424242"
+	testCardExp = "1229"
+	testCardCVV = "123"
+)
+
+func TestCardDataValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		card CardData
+		ok   bool
+	}{
+		{name: "valid", card: CardData{PAN: testCardPAN, Exp: testCardExp, CVV: testCardCVV}, ok: true},
+		{name: "bad luhn", card: CardData{PAN: "4242424242424241", Exp: testCardExp, CVV: testCardCVV}},
+		{name: "short pan", card: CardData{PAN: "42424242", Exp: testCardExp, CVV: testCardCVV}},
+		{name: "bad month", card: CardData{PAN: testCardPAN, Exp: "1329", CVV: testCardCVV}},
+		{name: "bad cvv", card: CardData{PAN: testCardPAN, Exp: testCardExp, CVV: "12a"}},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(
+			tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := tc.card.Validate()
+				if tc.ok && err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !tc.ok && err == nil {
+					t.Fatalf("expected validation error")
+				}
+			},
+		)
+	}
+}
+
+func TestCardDataStringDoesNotExposeSecrets(t *testing.T) {
+	t.Parallel()
+
+	card := CardData{PAN: testCardPAN, Exp: testCardExp, CVV: testCardCVV}
+	for _, out := range []string{card.String(), fmt.Sprintf("%v", card), fmt.Sprintf("%#v", card), fmt.Sprintf("%+v", &card)} {
+		if strings.Contains(out, testCardPAN) || strings.Contains(out, testCardCVV) {
+			t.Fatalf("formatted card data leaks secrets: %s", out)
+		}
+	}
+	if got := card.Masked().PAN; got != "424242******4242" {
+		t.Fatalf("masked PAN = %q, want 424242******4242", got)
+	}
+}
+
+func TestPaymentDirectDryRunPayloadIsMasked(t *testing.T) {
+	t.Parallel()
+
+	request := NewRequest().
+		WithAmount(1234).
+		WithCardData(testCardPAN, testCardExp, testCardCVV).
+		SaveCard("wallet-1")
+
+	var endpoint string
+	var payload any
+	client := NewClient(WithToken("merchant-token"))
+	_, err := client.PaymentDirect(
+		request,
+		DryRun(func(gotEndpoint string, gotPayload any) {
+			endpoint = gotEndpoint
+			payload = gotPayload
+		}),
+	)
+	if err != nil {
+		t.Fatalf("PaymentDirect() unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(endpoint, consts.PathInvoicePaymentDirect) {
+		t.Fatalf("endpoint = %q, want suffix %q", endpoint, consts.PathInvoicePaymentDirect)
+	}
+
+	got := decodePayloadMap(t, payload)
+	card, ok := got["cardData"].(map[string]any)
+	if !ok {
+		t.Fatalf("cardData is missing: %+v", got)
+	}
+	if card["pan"] != "424242******4242" || card["cvv"] != "***" {
+		t.Fatalf("dry-run cardData must be masked, got %+v", card)
+	}
+	if got["paymentType"] != string(PaymentTypeDebit) {
+		t.Fatalf("paymentType = %v, want %s", got["paymentType"], PaymentTypeDebit)
+	}
+	save, ok := got["saveCardData"].(map[string]any)
+	if !ok || save["saveCard"] != true || save["walletId"] != "wallet-1" {
+		t.Fatalf("unexpected saveCardData: %+v", got["saveCardData"])
+	}
+}
+
+func TestPaymentDirectRejectsInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	cases := map[string]*Request{
+		"no card data":    NewRequest().WithAmount(100),
+		"invalid card":    NewRequest().WithAmount(100).WithCardData("4242424242424241", testCardExp, testCardCVV),
+		"two sources":     NewRequest().WithAmount(100).WithCardData(testCardPAN, testCardExp, testCardCVV).WithCardToken("card-token"),
+		"card and aToken": NewRequest().WithAmount(100).WithCardData(testCardPAN, testCardExp, testCardCVV).WithAToken(`{"paymentData":{}}`),
+		"zero amount":     NewRequest().WithCardData(testCardPAN, testCardExp, testCardCVV),
+		"save no wallet":  NewRequest().WithAmount(100).WithCardData(testCardPAN, testCardExp, testCardCVV).EnableSaveCard(),
+	}
+
+	for name, request := range cases {
+		_, err := client.PaymentDirect(request, DryRun())
+		if !errors.Is(err, ErrValidation) {
+			t.Fatalf("%s: expected ErrValidation, got %v", name, err)
+		}
+	}
+}
+
+func TestPaymentDirectSendsRawCardButRecordsMasked(t *testing.T) {
+	t.Parallel()
+
+	storage := &captureStorage{}
+	rec := recorder.New(storage)
+
+	var sentBody string
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != consts.PathInvoicePaymentDirect {
+					t.Fatalf("unexpected path: %s", r.URL.Path)
+				}
+				body, _ := io.ReadAll(r.Body)
+				sentBody = string(body)
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"invoiceId":"inv-1","tdsUrl":"https://3ds.example/inv-1","status":"processing","amount":1234,"ccy":980,"createdDate":"2026-02-26T10:00:00Z","modifiedDate":"2026-02-26T10:00:00Z"}`))
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRecorder(rec),
+		WithToken("merchant-token"),
+	)
+
+	request := NewRequest().
+		WithAmount(1234).
+		WithReference("order-1").
+		WithCardData(testCardPAN, testCardExp, testCardCVV)
+
+	resp, err := client.PaymentDirect(request)
+	if err != nil {
+		t.Fatalf("PaymentDirect() unexpected error: %v", err)
+	}
+	if resp.TDSURL == nil || *resp.TDSURL != "https://3ds.example/inv-1" {
+		t.Fatalf("unexpected tdsUrl: %v", resp.TDSURL)
+	}
+
+	if !strings.Contains(sentBody, testCardPAN) || !strings.Contains(sentBody, `"cvv":"`+testCardCVV+`"`) {
+		t.Fatalf("raw card data must be sent to monobank, got %s", sentBody)
+	}
+
+	records := storage.snapshot()
+	if len(records) != 2 {
+		t.Fatalf("expected request+response records, got %d", len(records))
+	}
+	payload := string(records[0].Payload)
+	if strings.Contains(payload, testCardPAN) || strings.Contains(payload, `"cvv":"`+testCardCVV+`"`) {
+		t.Fatalf("recorded request leaks card data: %s", payload)
+	}
+	if records[0].Tags["operation"] != "payment_direct" {
+		t.Fatalf("expected operation=payment_direct, got %q", records[0].Tags["operation"])
+	}
+}
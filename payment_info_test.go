@@ -0,0 +1,71 @@
+package go_monobank
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaymentInfoRequiresInvoiceID(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+
+	_, err := client.PaymentInfo(NewRequest(), DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestPaymentInfoDecodesCardDetails(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Fatalf("unexpected method: %s", r.Method)
+				}
+				if r.URL.Path != "/api/merchant/invoice/payment-info" {
+					t.Fatalf("unexpected path: %s", r.URL.Path)
+				}
+				if r.URL.Query().Get("invoiceId") != "inv-1" {
+					t.Fatalf("unexpected invoiceId: %s", r.URL.RawQuery)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(
+					[]byte(`{"maskedPan":"444403******1902","approvalCode":"662476","rrn":"060189181768","tranId":"13194036","terminal":"MI001088","bank":"Universal Bank","paymentSystem":"visa","paymentMethod":"pan","fee":2,"country":"804","domesticCard":true}`),
+				)
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	info, err := client.PaymentInfo(NewRequest().WithInvoiceID("inv-1"))
+	if err != nil {
+		t.Fatalf("PaymentInfo() unexpected error: %v", err)
+	}
+
+	if info.MaskedPan == nil || *info.MaskedPan != "444403******1902" {
+		t.Fatalf("unexpected maskedPan: %v", info.MaskedPan)
+	}
+	if info.TranID == nil || *info.TranID != "13194036" {
+		t.Fatalf("unexpected tranId: %v", info.TranID)
+	}
+	if info.Bank == nil || *info.Bank != "Universal Bank" {
+		t.Fatalf("unexpected bank: %v", info.Bank)
+	}
+	if info.Country == nil || *info.Country != "804" {
+		t.Fatalf("unexpected country: %v", info.Country)
+	}
+	if info.Fee == nil || *info.Fee != 2 {
+		t.Fatalf("unexpected fee: %v", info.Fee)
+	}
+	if !info.IsDomesticCard() {
+		t.Fatalf("expected domestic card")
+	}
+}
@@ -0,0 +1,90 @@
+package go_monobank
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestQRDetailsRequiresQrID(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+
+	if _, err := client.QRDetails(NewRequest(), DryRun()); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+	if err := client.QRResetAmount(NewRequest(), DryRun()); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestCreateInvoiceRejectsSaveCardForQR(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	request := NewRequest().WithAmount(100).WithQrID("qr-1").SaveCard("wallet-1")
+
+	if _, err := client.CreateInvoice(request, DryRun()); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestQRFlow(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/api/merchant/qr/list":
+					_, _ = w.Write([]byte(`{"list":[{"shortQrId":"OBJE","qrId":"XJ_DiM4rTd5V","amountType":"merchant","pageUrl":"https://pay.monobank.ua/xJ_DiM4rTd5V"}]}`))
+				case "/api/merchant/qr/details":
+					if r.URL.Query().Get("qrId") != "XJ_DiM4rTd5V" {
+						t.Fatalf("unexpected qrId: %s", r.URL.RawQuery)
+					}
+					_, _ = w.Write([]byte(`{"shortQrId":"OBJE","invoiceId":"inv-1","amount":4200,"ccy":980}`))
+				case "/api/merchant/qr/reset-amount":
+					if r.Method != http.MethodPost {
+						t.Fatalf("unexpected method: %s", r.Method)
+					}
+					body, _ := io.ReadAll(r.Body)
+					if !strings.Contains(string(body), `"qrId":"XJ_DiM4rTd5V"`) {
+						t.Fatalf("unexpected body: %s", body)
+					}
+					w.WriteHeader(http.StatusOK)
+				default:
+					t.Fatalf("unexpected path: %s", r.URL.Path)
+				}
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	list, err := client.QRList(nil)
+	if err != nil {
+		t.Fatalf("QRList() unexpected error: %v", err)
+	}
+	if len(list.List) != 1 || list.List[0].AmountType != QRAmountMerchant {
+		t.Fatalf("unexpected QR list: %+v", list)
+	}
+
+	request := NewRequest().WithQrID(list.List[0].QrID)
+	details, err := client.QRDetails(request)
+	if err != nil {
+		t.Fatalf("QRDetails() unexpected error: %v", err)
+	}
+	if !details.HasAmount() || details.InvoiceID == nil || *details.InvoiceID != "inv-1" {
+		t.Fatalf("unexpected QR details: %+v", details)
+	}
+
+	if err = client.QRResetAmount(request); err != nil {
+		t.Fatalf("QRResetAmount() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package go_monobank
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token-bucket budget: Rate requests per second with bursts up to Burst.
+// Zero Rate means unlimited.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiterConfig configures client-side throttling keyed by resolved X-Token.
+type RateLimiterConfig struct {
+	// Default budget is shared by all endpoints of a token that have no own budget.
+	Default RateLimit
+	// Endpoints holds per-endpoint budgets keyed by API path (e.g. consts.PathWalletPayment).
+	// Each of them has its own bucket per token.
+	Endpoints map[string]RateLimit
+}
+
+type rateLimiter struct {
+	defaultLimit RateLimit
+	endpoints    map[string]RateLimit
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+func newRateLimiter(cfg RateLimiterConfig) *rateLimiter {
+	endpoints := make(map[string]RateLimit, len(cfg.Endpoints))
+	for path, limit := range cfg.Endpoints {
+		endpoints[normalizeRecorderPath(path)] = limit
+	}
+	return &rateLimiter{
+		defaultLimit: cfg.Default,
+		endpoints:    endpoints,
+		buckets:      make(map[string]*tokenBucket),
+		now:          time.Now,
+	}
+}
+
+// wait blocks until a request to path with token is allowed.
+// If ctx deadline comes before that, it fails fast with RateLimitWaitError.
+func (l *rateLimiter) wait(ctx context.Context, token, path string) error {
+	bucket := l.bucket(token, path)
+	if bucket == nil {
+		return nil
+	}
+
+	now := l.now()
+	delay := bucket.reserve(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		bucket.cancel()
+		return &RateLimitWaitError{Endpoint: normalizeRecorderPath(path), Wait: delay}
+	}
+
+	logger.Debug("Rate limiter: waiting %s for path=%s", delay, path)
+	if !sleepContext(ctx, delay) {
+		bucket.cancel()
+		return &RateLimitWaitError{Endpoint: normalizeRecorderPath(path), Wait: delay, Cause: ctx.Err()}
+	}
+	return nil
+}
+
+// pause stops the bucket of token/path for d (Retry-After returned by API).
+func (l *rateLimiter) pause(token, path string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	if bucket := l.bucket(token, path); bucket != nil {
+		logger.Warn("Rate limiter: pausing path=%s for %s", path, d)
+		bucket.pause(l.now().Add(d))
+	}
+}
+
+func (l *rateLimiter) bucket(token, path string) *tokenBucket {
+	path = normalizeRecorderPath(path)
+	limit, own := l.endpoints[path]
+	if !own {
+		limit = l.defaultLimit
+	}
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	key := token
+	if own {
+		key += "\x00" + path
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newTokenBucket(limit, l.now())
+		l.buckets[key] = b
+	}
+	return b
+}
+
+type tokenBucket struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst, last: now}
+}
+
+// reserve takes one token and returns how long the caller must wait before using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if paused := b.pausedUntil.Sub(now); paused > delay {
+		delay = paused
+	}
+	return delay
+}
+
+// cancel returns a token taken by reserve that will not be used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+func (b *tokenBucket) pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
@@ -0,0 +1,138 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stremovskyy/go-monobank/consts"
+)
+
+func TestTokenBucketReserve(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	b := newTokenBucket(RateLimit{Rate: 2, Burst: 2}, now)
+
+	if d := b.reserve(now); d != 0 {
+		t.Fatalf("first reserve wait = %s, want 0", d)
+	}
+	if d := b.reserve(now); d != 0 {
+		t.Fatalf("second reserve wait = %s, want 0", d)
+	}
+	if d := b.reserve(now); d != 500*time.Millisecond {
+		t.Fatalf("third reserve wait = %s, want 500ms", d)
+	}
+	b.cancel()
+
+	b.pause(now.Add(3 * time.Second))
+	if d := b.reserve(now.Add(time.Second)); d != 2*time.Second {
+		t.Fatalf("paused reserve wait = %s, want 2s", d)
+	}
+}
+
+func TestRateLimiterKeysBucketsByTokenAndEndpoint(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(
+		RateLimiterConfig{
+			Default:   RateLimit{Rate: 1, Burst: 1},
+			Endpoints: map[string]RateLimit{consts.PathWalletPayment: {Rate: 1, Burst: 1}},
+		},
+	)
+
+	if l.bucket("token-a", consts.PathInvoiceStatus+"?invoiceId=1") != l.bucket("token-a", consts.PathWallet) {
+		t.Fatalf("endpoints without own budget must share token bucket")
+	}
+	if l.bucket("token-a", consts.PathInvoiceStatus) == l.bucket("token-b", consts.PathInvoiceStatus) {
+		t.Fatalf("different tokens must not share bucket")
+	}
+	if l.bucket("token-a", consts.PathWalletPayment) == l.bucket("token-a", consts.PathInvoiceStatus) {
+		t.Fatalf("endpoint with own budget must have own bucket")
+	}
+	if newRateLimiter(RateLimiterConfig{}).bucket("token-a", consts.PathInvoiceStatus) != nil {
+		t.Fatalf("zero rate must be unlimited")
+	}
+}
+
+func TestRateLimiterFailsFastWhenWaitExceedsDeadline(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"invoiceId":"inv-1","status":"success","amount":100,"ccy":980}`))
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithToken("merchant-token"),
+		WithRateLimiter(RateLimiterConfig{Default: RateLimit{Rate: 0.1, Burst: 1}}),
+	)
+
+	if _, err := client.Status(NewRequest().WithInvoiceID("inv-1")); err != nil {
+		t.Fatalf("first Status() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := client.StatusContext(ctx, NewRequest().WithInvoiceID("inv-1"))
+	if !errors.Is(err, ErrClientRateLimited) {
+		t.Fatalf("expected ErrClientRateLimited, got %v", err)
+	}
+	var waitErr *RateLimitWaitError
+	if !errors.As(err, &waitErr) || waitErr.Wait <= 0 {
+		t.Fatalf("expected RateLimitWaitError with wait, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+
+	// Other token has its own bucket.
+	if _, err := client.Status(NewRequest().WithToken("other-token").WithInvoiceID("inv-1")); err != nil {
+		t.Fatalf("Status() with other token unexpected error: %v", err)
+	}
+}
+
+func TestRateLimiterPausesBucketOnRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "30")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithToken("merchant-token"),
+		WithRateLimiter(RateLimiterConfig{Default: RateLimit{Rate: 100, Burst: 10}}),
+	)
+
+	if _, err := client.Status(NewRequest().WithInvoiceID("inv-1")); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited from API, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.StatusContext(ctx, NewRequest().WithInvoiceID("inv-1"))
+	if !errors.Is(err, ErrClientRateLimited) {
+		t.Fatalf("expected ErrClientRateLimited while paused, got %v", err)
+	}
+}
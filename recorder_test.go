@@ -174,3 +174,37 @@ func TestPaymentRecordsErrorOnTransportFailure(t *testing.T) {
 		t.Fatalf("error record is missing")
 	}
 }
+
+func TestInvoiceCreateRecordsCallerOperation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"invoiceId":"inv-1","pageUrl":"https://pay.example/inv-1"}`))
+			},
+		),
+	)
+	defer server.Close()
+
+	storage := &captureStorage{}
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"), WithRecorder(recorder.New(storage)))
+
+	if _, err := client.CreateInvoice(NewRequest().WithAmount(100)); err != nil {
+		t.Fatalf("CreateInvoice() error: %v", err)
+	}
+	if _, err := client.Verification(NewRequest().WithPaymentType(PaymentTypeVerification).SaveCard("wallet-1")); err != nil {
+		t.Fatalf("Verification() error: %v", err)
+	}
+
+	var operations []string
+	for _, record := range storage.snapshot() {
+		if record.Type == recorder.RecordTypeRequest {
+			operations = append(operations, record.Tags["operation"])
+		}
+	}
+	if got := strings.Join(operations, ","); got != "create_invoice,verification" {
+		t.Fatalf("operations = %s, want create_invoice,verification", got)
+	}
+}
@@ -0,0 +1,280 @@
+package go_monobank
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redactedMask = "****"
+
+// RedactionConfig controls masking of secrets and PII in debug logs, recorder payloads,
+// default DryRun output and APIError/DecodeError bodies. Redaction is on by default.
+//
+// Values of listed JSON fields are masked wherever they appear in a payload: tokens keep
+// the last 4 characters, emails keep the first letter and domain. Emails are also masked
+// inside any other string value; card numbers only inside free-text fields such as errText
+// (see redactTextFields), so long numeric ids and references stay intact.
+type RedactionConfig struct {
+	// Fields are JSON keys whose values are masked. Matching ignores case, '-' and '_',
+	// so "X-Token" also covers "xToken". Nil means DefaultRedactedFields();
+	// extend it with append(DefaultRedactedFields(), "phone").
+	Fields []string
+	// Disabled turns redaction off (e.g. for local debugging against sandbox).
+	Disabled bool
+}
+
+// DefaultRedactedFields returns fields masked by default.
+func DefaultRedactedFields() []string {
+	return []string{
+		"cardToken",
+		"aToken",
+		"token",
+		"X-Token",
+		"pan",
+		"cvv",
+		"exp",
+		"customerEmails",
+		"email",
+	}
+}
+
+// redactTextFields are free-text fields (error descriptions, comments) scanned for card numbers.
+var redactTextFields = map[string]struct{}{
+	"errtext":          {},
+	"errortext":        {},
+	"errdescription":   {},
+	"errordescription": {},
+	"description":      {},
+	"failurereason":    {},
+	"message":          {},
+	"comment":          {},
+	"destination":      {},
+}
+
+var (
+	redactEmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	redactPANPattern   = regexp.MustCompile(`\b\d{13,19}\b`)
+)
+
+// redactor applies RedactionConfig to payloads. Nil redactor returns payloads unchanged.
+type redactor struct {
+	fields map[string]struct{}
+}
+
+func newRedactor(cfg RedactionConfig) *redactor {
+	if cfg.Disabled {
+		return nil
+	}
+	fields := cfg.Fields
+	if fields == nil {
+		fields = DefaultRedactedFields()
+	}
+	r := &redactor{fields: make(map[string]struct{}, len(fields))}
+	for _, field := range fields {
+		if key := redactKey(field); key != "" {
+			r.fields[key] = struct{}{}
+		}
+	}
+	return r
+}
+
+// body masks a JSON (or plain-text) payload. Payloads without sensitive data are returned as is.
+func (r *redactor) body(body []byte) []byte {
+	if r == nil || len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return []byte(redactText(string(body)))
+	}
+
+	masked, changed := r.value("", v)
+	if !changed {
+		return body
+	}
+	out, err := marshalNoEscape(masked)
+	if err != nil {
+		return []byte(redactText(string(body)))
+	}
+	return out
+}
+
+// url masks query parameters named like sensitive fields (e.g. ?cardToken=...).
+func (r *redactor) url(raw string) string {
+	if r == nil {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.RawQuery == "" {
+		return raw
+	}
+	query, changed := r.query(u.Query())
+	if !changed {
+		return raw
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func (r *redactor) query(values url.Values) (url.Values, bool) {
+	if r == nil {
+		return values, false
+	}
+	changed := false
+	for key, items := range values {
+		if _, ok := r.fields[redactKey(key)]; !ok {
+			continue
+		}
+		masked := make([]string, len(items))
+		for i, item := range items {
+			masked[i] = maskString(item)
+			changed = changed || masked[i] != item
+		}
+		values[key] = masked
+	}
+	return values, changed
+}
+
+// payload masks a value before the default DryRun handler prints it. Returns json.RawMessage
+// when anything was masked; custom DryRun handlers never see it.
+func (r *redactor) payload(payload any) any {
+	if r == nil || payload == nil {
+		return payload
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return payload
+	}
+	masked := r.body(raw)
+	if bytes.Equal(masked, raw) {
+		return payload
+	}
+	return json.RawMessage(masked)
+}
+
+// value masks v found under JSON key (empty for the top level and plain-text bodies).
+func (r *redactor) value(key string, v any) (any, bool) {
+	switch val := v.(type) {
+	case map[string]any:
+		changed := false
+		for k, item := range val {
+			var (
+				masked     any
+				itemChange bool
+			)
+			if _, ok := r.fields[redactKey(k)]; ok {
+				masked, itemChange = maskField(item)
+			} else {
+				masked, itemChange = r.value(k, item)
+			}
+			if itemChange {
+				val[k] = masked
+				changed = true
+			}
+		}
+		return val, changed
+	case []any:
+		changed := false
+		for i, item := range val {
+			if masked, itemChange := r.value(key, item); itemChange {
+				val[i] = masked
+				changed = true
+			}
+		}
+		return val, changed
+	case string:
+		var masked string
+		if _, ok := redactTextFields[redactKey(key)]; ok {
+			masked = redactText(val)
+		} else {
+			masked = redactEmails(val)
+		}
+		return masked, masked != val
+	default:
+		return v, false
+	}
+}
+
+// maskField masks value of a sensitive field whatever its type.
+func maskField(v any) (any, bool) {
+	switch val := v.(type) {
+	case nil:
+		return nil, false
+	case string:
+		masked := maskString(val)
+		return masked, masked != val
+	case []any:
+		changed := false
+		for i, item := range val {
+			if masked, itemChange := maskField(item); itemChange {
+				val[i] = masked
+				changed = true
+			}
+		}
+		return val, changed
+	case map[string]any:
+		changed := false
+		for k, item := range val {
+			if masked, itemChange := maskField(item); itemChange {
+				val[k] = masked
+				changed = true
+			}
+		}
+		return val, changed
+	default:
+		return redactedMask, true
+	}
+}
+
+// maskString keeps the first letter and domain of emails and the last 4 characters of
+// long values. Values already containing '*' are treated as masked, so masking recorded
+// or pre-masked (e.g. cardData) payloads again gives the same result.
+func maskString(s string) string {
+	if s == "" || strings.Contains(s, "*") {
+		return s
+	}
+	if at := strings.LastIndex(s, "@"); at > 0 && !strings.ContainsAny(s, " {") {
+		return s[:1] + "***" + s[at:]
+	}
+	if len(s) >= 8 {
+		return redactedMask + s[len(s)-4:]
+	}
+	return redactedMask
+}
+
+// redactEmails masks emails inside any string (e.g. Apple/Google Pay tokens sent as JSON strings).
+func redactEmails(s string) string {
+	return redactEmailPattern.ReplaceAllStringFunc(s, maskString)
+}
+
+// redactText masks emails and Luhn-valid card numbers inside free text.
+func redactText(s string) string {
+	s = redactEmails(s)
+	return redactPANPattern.ReplaceAllStringFunc(s, func(digits string) string {
+		if !luhnValid(digits) {
+			return digits
+		}
+		return digits[:6] + strings.Repeat("*", len(digits)-10) + digits[len(digits)-4:]
+	})
+}
+
+func redactKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	return strings.NewReplacer("-", "", "_", "").Replace(key)
+}
+
+func marshalNoEscape(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,195 @@
+package go_monobank
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stremovskyy/recorder"
+
+	"github.com/stremovskyy/go-monobank/consts"
+)
+
+func newRedactionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case consts.PathWalletPayment:
+			_, _ = w.Write([]byte(`{"invoiceId":"inv-1","status":"success","amount":100,"ccy":980,"walletData":{"cardToken":"tok_secret_1234","walletId":"w-1"}}`))
+		case consts.PathWalletCard:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errCode":"BAD_REQUEST","errText":"card 4111111111111111 rejected for buyer@example.com"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func redactionPayment() *Request {
+	return NewRequest().
+		WithCardToken("tok_secret_1234").
+		WithAmount(100).
+		WithInitiationKind(InitiationMerchant).
+		WithReference("order-secret")
+}
+
+func recordedPayloads(storage *captureStorage) string {
+	var sb strings.Builder
+	for _, record := range storage.snapshot() {
+		sb.Write(record.Payload)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func TestRecorderPayloadsAreRedacted(t *testing.T) {
+	t.Parallel()
+
+	storage := &captureStorage{}
+	client := NewClient(
+		WithBaseURL(newRedactionServer(t).URL),
+		WithToken("merchant-token"),
+		WithRecorder(recorder.New(storage)),
+	)
+
+	if _, err := client.Payment(redactionPayment()); err != nil {
+		t.Fatalf("Payment() error: %v", err)
+	}
+	if err := client.DeleteWalletCard(NewRequest().WithCardToken("tok_secret_1234")); err != nil {
+		t.Fatalf("DeleteWalletCard() error: %v", err)
+	}
+
+	payloads := recordedPayloads(storage)
+	if strings.Contains(payloads, "tok_secret_1234") {
+		t.Fatalf("card token leaked into recorder payloads:\n%s", payloads)
+	}
+	if !strings.Contains(payloads, `"cardToken":"****1234"`) || !strings.Contains(payloads, "cardToken=%2A%2A%2A%2A1234") {
+		t.Fatalf("expected masked card token in body and query:\n%s", payloads)
+	}
+	if !strings.Contains(payloads, "order-secret") {
+		t.Fatalf("reference must not be masked by default:\n%s", payloads)
+	}
+}
+
+func TestAPIErrorBodyIsRedacted(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithBaseURL(newRedactionServer(t).URL), WithToken("merchant-token"))
+
+	_, err := client.Status(NewRequest().WithInvoiceID("inv-1"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	body := string(apiErr.Body)
+	if strings.Contains(body, "4111111111111111") || strings.Contains(body, "buyer@example.com") {
+		t.Fatalf("PII leaked into APIError body: %s", body)
+	}
+	if !strings.Contains(body, "411111******1111") || !strings.Contains(body, "b***@example.com") {
+		t.Fatalf("unexpected APIError body: %s", body)
+	}
+}
+
+func TestDryRunRedactsOnlyDefaultPrinter(t *testing.T) {
+	t.Parallel()
+
+	mono := NewClient(WithToken("merchant-token"))
+
+	var payload any
+	custom := DryRun(func(_ string, gotPayload any) { payload = gotPayload })
+	if err := mono.DeleteWalletCard(NewRequest().WithCardToken("tok_secret_1234"), custom); err != nil {
+		t.Fatalf("DeleteWalletCard() error: %v", err)
+	}
+	if got, ok := payload.(map[string]string); !ok || got["cardToken"] != "tok_secret_1234" {
+		t.Fatalf("custom handler must receive typed raw payload, got %#v", payload)
+	}
+
+	// Default printer: swap its output for a capture, keep the rest of DryRun() setup.
+	opts := &runOptions{}
+	DryRun()(opts)
+	var endpoint string
+	opts.dryRunHandle = func(gotEndpoint string, gotPayload any) { endpoint, payload = gotEndpoint, gotPayload }
+
+	mono.(*client).handleDryRun(opts, "https://api.monobank.ua/api/merchant/wallet/card?cardToken=tok_secret_1234", map[string]string{"cardToken": "tok_secret_1234"})
+	raw, _ := json.Marshal(payload)
+	if strings.Contains(endpoint, "tok_secret_1234") || string(raw) != `{"cardToken":"****1234"}` {
+		t.Fatalf("default printer must get redacted values, got %s %s", endpoint, raw)
+	}
+}
+
+func TestWithRedactionConfig(t *testing.T) {
+	t.Parallel()
+
+	server := newRedactionServer(t)
+
+	disabled := &captureStorage{}
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithToken("merchant-token"),
+		WithRecorder(recorder.New(disabled)),
+		WithRedaction(RedactionConfig{Disabled: true}),
+	)
+	if _, err := client.Payment(redactionPayment()); err != nil {
+		t.Fatalf("Payment() error: %v", err)
+	}
+	if !strings.Contains(recordedPayloads(disabled), "tok_secret_1234") {
+		t.Fatalf("disabled redaction must keep raw payloads")
+	}
+
+	custom := &captureStorage{}
+	client = NewClient(
+		WithBaseURL(server.URL),
+		WithToken("merchant-token"),
+		WithRecorder(recorder.New(custom)),
+		WithRedaction(RedactionConfig{Fields: append(DefaultRedactedFields(), "reference")}),
+	)
+	if _, err := client.Payment(redactionPayment()); err != nil {
+		t.Fatalf("Payment() error: %v", err)
+	}
+	payloads := recordedPayloads(custom)
+	if strings.Contains(payloads, "order-secret") || strings.Contains(payloads, "tok_secret_1234") {
+		t.Fatalf("custom fields must be masked:\n%s", payloads)
+	}
+}
+
+func TestRedactorIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	r := newRedactor(RedactionConfig{})
+	body := []byte(`{"cardToken":"tok_secret_1234","customerEmails":["buyer@example.com"],"cvv":123,"amount":100}`)
+
+	once := r.body(body)
+	twice := r.body(once)
+	if string(once) != string(twice) {
+		t.Fatalf("masking is not idempotent:\n%s\n%s", once, twice)
+	}
+	want := `{"amount":100,"cardToken":"****1234","customerEmails":["b***@example.com"],"cvv":"****"}`
+	if string(once) != want {
+		t.Fatalf("body = %s, want %s", once, want)
+	}
+
+	plain := []byte(`{"invoiceId":"inv-1","amount":100}`)
+	if got := r.body(plain); string(got) != string(plain) {
+		t.Fatalf("payload without PII must be returned as is, got %s", got)
+	}
+}
+
+func TestRedactorMasksCardNumbersOnlyInFreeText(t *testing.T) {
+	t.Parallel()
+
+	r := newRedactor(RedactionConfig{})
+	body := []byte(`{"reference":"4111111111111111","errText":"card 4111111111111111 declined","pan":"4111111111111111"}`)
+
+	got := string(r.body(body))
+	want := `{"errText":"card 411111******1111 declined","pan":"****1111","reference":"4111111111111111"}`
+	if got != want {
+		t.Fatalf("body = %s, want %s", got, want)
+	}
+}
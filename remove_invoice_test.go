@@ -0,0 +1,106 @@
+package go_monobank
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRemoveInvoiceRequiresInvoiceID(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+
+	err := client.RemoveInvoice(NewRequest(), DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestRemoveInvoice(t *testing.T) {
+	t.Parallel()
+
+	// errText is not parsed: only the invoice state decides.
+	const rejected = `{"errCode":"BAD_REQUEST","errText":"any wording"}`
+
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		invoice    InvoiceStatus // reported by invoice/status; empty means 404
+		wantFinal  bool
+		wantAPIErr error
+	}{
+		{name: "removed", status: http.StatusOK, body: `{}`},
+		{name: "already paid", status: http.StatusBadRequest, body: rejected, invoice: InvoiceSuccess, wantFinal: true},
+		{name: "already expired", status: http.StatusBadRequest, body: rejected, invoice: InvoiceExpired, wantFinal: true},
+		{name: "already reversed", status: http.StatusBadRequest, body: rejected, invoice: InvoiceReversed, wantFinal: true},
+		{name: "bad request for pending invoice", status: http.StatusBadRequest, body: rejected, invoice: InvoiceCreated, wantAPIErr: ErrBadRequest},
+		{name: "status check fails", status: http.StatusBadRequest, body: rejected, wantAPIErr: ErrBadRequest},
+		{name: "not found", status: http.StatusNotFound, body: `{"errCode":"NOT_FOUND","errText":"invoice not found"}`, invoice: InvoiceSuccess, wantAPIErr: ErrNotFound},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(
+			tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				server := httptest.NewServer(
+					http.HandlerFunc(
+						func(w http.ResponseWriter, r *http.Request) {
+							w.Header().Set("Content-Type", "application/json")
+							if r.Method == http.MethodGet && r.URL.Path == "/api/merchant/invoice/status" {
+								if tc.status != http.StatusBadRequest {
+									t.Fatalf("status must be checked only after 400, got %d", tc.status)
+								}
+								if tc.invoice == "" {
+									w.WriteHeader(http.StatusNotFound)
+									_, _ = w.Write([]byte(`{"errCode":"NOT_FOUND","errText":"invoice not found"}`))
+									return
+								}
+								_, _ = w.Write([]byte(`{"invoiceId":"inv-1","status":"` + string(tc.invoice) + `"}`))
+								return
+							}
+							if r.Method != http.MethodPost || r.URL.Path != "/api/merchant/invoice/remove" {
+								t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+							}
+							body, _ := io.ReadAll(r.Body)
+							if !strings.Contains(string(body), `"invoiceId":"inv-1"`) {
+								t.Fatalf("unexpected body: %s", body)
+							}
+							w.WriteHeader(tc.status)
+							_, _ = w.Write([]byte(tc.body))
+						},
+					),
+				)
+				defer server.Close()
+
+				client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+				err := client.RemoveInvoice(NewRequest().WithInvoiceID("inv-1"))
+
+				switch {
+				case tc.wantFinal:
+					if !errors.Is(err, ErrInvoiceAlreadyFinal) {
+						t.Fatalf("expected ErrInvoiceAlreadyFinal, got %v", err)
+					}
+					var apiErr *APIError
+					if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+						t.Fatalf("expected wrapped APIError, got %v", err)
+					}
+				case tc.wantAPIErr != nil:
+					if !errors.Is(err, tc.wantAPIErr) || errors.Is(err, ErrInvoiceAlreadyFinal) {
+						t.Fatalf("expected %v only, got %v", tc.wantAPIErr, err)
+					}
+				default:
+					if err != nil {
+						t.Fatalf("RemoveInvoice() unexpected error: %v", err)
+					}
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,273 @@
+package go_monobank
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stremovskyy/recorder"
+)
+
+// ReplayMode controls how strictly ReplayTransport matches requests.
+type ReplayMode int
+
+const (
+	// ReplayStrict serves every recorded interaction once, in recorded order: a request
+	// must match method, normalized path (with query) and body of the next unserved one.
+	ReplayStrict ReplayMode = iota
+	// ReplayLenient prefers an exact match, falls back to method and path without query
+	// (body ignored), and serves the last match again when matching interactions run out.
+	ReplayLenient
+)
+
+// ReplayTransport is an http.RoundTripper that serves responses captured by WithRecorder
+// into a Cassette (or another storage, see CassetteFromStorage), so regression tests run
+// offline against real traffic:
+//
+//	client := go_monobank.NewClient(
+//		go_monobank.WithToken("replay"),
+//		go_monobank.WithClient(&http.Client{Transport: replay}),
+//	)
+//
+// Requests are matched by method, path without base URL (query sorted) and JSON body
+// compared structurally after default redaction (see RedactionConfig), so recorded masked
+// tokens still match. Bodies changed otherwise before recording (raw card data, custom
+// redaction fields, recorder scrubbers) match only in ReplayLenient mode.
+// Unmatched requests fail with ErrReplayMismatch.
+type ReplayTransport struct {
+	mode     ReplayMode
+	redactor *redactor
+
+	mu           sync.Mutex
+	interactions []*replayInteraction
+}
+
+type replayInteraction struct {
+	requestID  string
+	method     string
+	route      string
+	path       string
+	body       string
+	statusCode int
+	response   []byte
+	used       bool
+}
+
+// NewReplayTransport builds transport from request/response records of cassette.
+// Requests without a recorded response (transport errors, validation errors) are skipped.
+func NewReplayTransport(cassette *Cassette, mode ReplayMode) (*ReplayTransport, error) {
+	if cassette == nil {
+		return nil, &ValidationError{Op: "replay", Msg: "cassette is nil"}
+	}
+
+	var (
+		order    []string
+		requests = make(map[string]recorder.Record)
+		replies  = make(map[string]recorder.Record)
+	)
+	for _, record := range cassette.Records() {
+		switch record.Type {
+		case recorder.RecordTypeRequest:
+			if _, ok := requests[record.RequestID]; !ok {
+				order = append(order, record.RequestID)
+			}
+			requests[record.RequestID] = record
+		case recorder.RecordTypeResponse:
+			replies[record.RequestID] = record
+		}
+	}
+
+	t := &ReplayTransport{mode: mode, redactor: newRedactor(RedactionConfig{})}
+	for _, id := range order {
+		reply, ok := replies[id]
+		if !ok {
+			continue
+		}
+		interaction, err := t.newInteraction(requests[id], reply)
+		if err != nil {
+			return nil, err
+		}
+		t.interactions = append(t.interactions, interaction)
+	}
+	return t, nil
+}
+
+// Pending returns how many recorded interactions were not served yet.
+func (t *ReplayTransport) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := 0
+	for _, interaction := range t.interactions {
+		if !interaction.used {
+			n++
+		}
+	}
+	return n
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("replay: read request body: %w", err)
+		}
+	}
+
+	method := strings.ToUpper(req.Method)
+	route := normalizeReplayRoute(req.URL.Path)
+	query, _ := t.redactor.query(req.URL.Query())
+	path := route + normalizeReplayQuery(query)
+	normalized := normalizeReplayBody(t.redactor.body(body))
+
+	interaction := t.match(method, route, path, normalized)
+	if interaction == nil {
+		logger.Warn("Replay: no recorded interaction for method=%s path=%s", method, path)
+		return nil, &ReplayMismatchError{Method: method, Path: path, Body: normalized}
+	}
+	logger.Debug("Replay: serving request_id=%s for method=%s path=%s", interaction.requestID, method, path)
+
+	return &http.Response{
+		Status:        strconv.Itoa(interaction.statusCode) + " " + http.StatusText(interaction.statusCode),
+		StatusCode:    interaction.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(interaction.response)),
+		ContentLength: int64(len(interaction.response)),
+		Request:       req,
+	}, nil
+}
+
+func (t *ReplayTransport) match(method, route, path, body string) *replayInteraction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exact := func(i *replayInteraction) bool {
+		return i.method == method && i.path == path && i.body == body
+	}
+
+	if t.mode != ReplayLenient {
+		// Only the next unserved interaction may match.
+		for _, interaction := range t.interactions {
+			if interaction.used {
+				continue
+			}
+			if !exact(interaction) {
+				return nil
+			}
+			interaction.used = true
+			return interaction
+		}
+		return nil
+	}
+
+	loose := func(i *replayInteraction) bool {
+		return i.method == method && i.route == route
+	}
+	candidates := []func(*replayInteraction) bool{exact, loose}
+	for _, matches := range candidates {
+		for _, interaction := range t.interactions {
+			if !interaction.used && matches(interaction) {
+				interaction.used = true
+				return interaction
+			}
+		}
+	}
+	for _, matches := range candidates {
+		for i := len(t.interactions) - 1; i >= 0; i-- {
+			if matches(t.interactions[i]) {
+				return t.interactions[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (t *ReplayTransport) newInteraction(request, reply recorder.Record) (*replayInteraction, error) {
+	method := strings.ToUpper(request.Tags["method"])
+	route := normalizeReplayRoute(request.Tags["path"])
+	if method == "" || route == "" {
+		return nil, &DecodeError{Op: "replay", Msg: "request " + request.RequestID + " has no method/path tags"}
+	}
+
+	interaction := &replayInteraction{
+		requestID:  request.RequestID,
+		method:     method,
+		route:      route,
+		path:       route,
+		statusCode: http.StatusOK,
+		response:   reply.Payload,
+	}
+
+	// Requests without body are recorded as {"method":...,"url":...} (see requestPayload).
+	var bodyless map[string]string
+	if err := json.Unmarshal(request.Payload, &bodyless); err == nil && len(bodyless) == 2 && strings.EqualFold(bodyless["method"], method) && bodyless["url"] != "" {
+		if u, err := url.Parse(bodyless["url"]); err == nil {
+			query, _ := t.redactor.query(u.Query())
+			interaction.path = route + normalizeReplayQuery(query)
+		}
+	} else {
+		interaction.body = normalizeReplayBody(t.redactor.body(request.Payload))
+	}
+
+	if raw := reply.Tags["status_code"]; raw != "" {
+		code, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, &DecodeError{Op: "replay", Msg: "response " + reply.RequestID + " has invalid status_code tag " + strconv.Quote(raw), Cause: err}
+		}
+		interaction.statusCode = code
+	}
+
+	// Empty bodies are recorded as {"status_code":N} (see responsePayload).
+	var placeholder map[string]int
+	if err := json.Unmarshal(reply.Payload, &placeholder); err == nil && len(placeholder) == 1 && placeholder["status_code"] == interaction.statusCode {
+		interaction.response = nil
+	}
+	return interaction, nil
+}
+
+func normalizeReplayRoute(path string) string {
+	path = normalizeRecorderPath(path)
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	return path
+}
+
+func normalizeReplayQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	// Encode sorts by key.
+	return "?" + query.Encode()
+}
+
+// normalizeReplayBody makes JSON bodies comparable regardless of key order and whitespace.
+func normalizeReplayBody(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return string(body)
+	}
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(normalized)
+}
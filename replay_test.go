@@ -0,0 +1,217 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stremovskyy/recorder"
+
+	"github.com/stremovskyy/go-monobank/consts"
+)
+
+func paymentRequest(amount int64) *Request {
+	return NewRequest().
+		WithCardToken("card-1").
+		WithAmount(amount).
+		WithInitiationKind(InitiationMerchant).
+		WithReference("order-1")
+}
+
+// recordTestCassette captures status, payment and an API error into a JSONL cassette.
+func recordTestCassette(t *testing.T) string {
+	t.Helper()
+
+	cassette := NewCassette()
+	recordTestTraffic(t, cassette)
+
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	if err := cassette.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	return path
+}
+
+// recordTestTraffic records status, payment and an API error into storage.
+func recordTestTraffic(t *testing.T, storage recorder.Storage) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == consts.PathInvoiceStatus && r.URL.Query().Get("invoiceId") == "inv-1":
+			_, _ = w.Write([]byte(`{"invoiceId":"inv-1","status":"success","amount":100,"ccy":980}`))
+		case r.URL.Path == consts.PathInvoiceStatus:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errCode":"NOT_FOUND","errText":"invoice not found"}`))
+		case r.URL.Path == consts.PathWalletPayment:
+			_, _ = w.Write([]byte(`{"invoiceId":"inv-2","status":"success","amount":100,"ccy":980}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("token"), WithRecorder(recorder.New(storage)))
+
+	if _, err := client.Status(NewRequest().WithInvoiceID("inv-1")); err != nil {
+		t.Fatalf("record status: %v", err)
+	}
+	if _, err := client.Payment(paymentRequest(100)); err != nil {
+		t.Fatalf("record payment: %v", err)
+	}
+	if _, err := client.Status(NewRequest().WithInvoiceID("missing")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("record not found: %v", err)
+	}
+}
+
+func newReplayClient(t *testing.T, path string, mode ReplayMode) (Monobank, *ReplayTransport) {
+	t.Helper()
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("LoadCassette() error: %v", err)
+	}
+	transport, err := NewReplayTransport(cassette, mode)
+	if err != nil {
+		t.Fatalf("NewReplayTransport() error: %v", err)
+	}
+	return NewClient(WithToken("other-token"), WithClient(&http.Client{Transport: transport})), transport
+}
+
+func TestReplayTransportStrict(t *testing.T) {
+	t.Parallel()
+
+	client, transport := newReplayClient(t, recordTestCassette(t), ReplayStrict)
+
+	status, err := client.Status(NewRequest().WithInvoiceID("inv-1"))
+	if err != nil || status.InvoiceID != "inv-1" || !status.IsSuccess() {
+		t.Fatalf("replayed status = %+v, %v", status, err)
+	}
+	payment, err := client.Payment(paymentRequest(100))
+	if err != nil || payment.InvoiceID != "inv-2" {
+		t.Fatalf("replayed payment = %+v, %v", payment, err)
+	}
+	var apiErr *APIError
+	if _, err := client.Status(NewRequest().WithInvoiceID("missing")); !errors.As(err, &apiErr) || apiErr.ErrCode != "NOT_FOUND" {
+		t.Fatalf("expected replayed 404, got %v", err)
+	}
+	if transport.Pending() != 0 {
+		t.Fatalf("pending = %d, want 0", transport.Pending())
+	}
+
+	if _, err := client.Status(NewRequest().WithInvoiceID("inv-1")); !errors.Is(err, ErrReplayMismatch) {
+		t.Fatalf("strict mode must serve interaction once, got %v", err)
+	}
+}
+
+// indexStorage is a recorder.Storage that, like a database, returns payloads and
+// request IDs but not tags.
+type indexStorage struct {
+	mu       sync.Mutex
+	order    []string
+	payloads map[recorder.RecordType]map[string][]byte
+	tags     map[string][]string
+}
+
+func (s *indexStorage) Save(_ context.Context, record recorder.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.payloads == nil {
+		s.payloads = make(map[recorder.RecordType]map[string][]byte)
+		s.tags = make(map[string][]string)
+	}
+	if s.payloads[record.Type] == nil {
+		s.payloads[record.Type] = make(map[string][]byte)
+	}
+	s.payloads[record.Type][record.RequestID] = record.Payload
+	for key, value := range record.Tags {
+		s.tags[key+":"+value] = append(s.tags[key+":"+value], record.RequestID)
+	}
+	return nil
+}
+
+func (s *indexStorage) Load(_ context.Context, recordType recorder.RecordType, requestID string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payload, ok := s.payloads[recordType][requestID]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return payload, nil
+}
+
+func (s *indexStorage) FindByTag(_ context.Context, tag string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.tags[tag]...), nil
+}
+
+func TestReplayFromRecorderStorage(t *testing.T) {
+	t.Parallel()
+
+	storage := &indexStorage{}
+	recordTestTraffic(t, storage)
+
+	cassette, err := CassetteFromStorage(context.Background(), storage)
+	if err != nil {
+		t.Fatalf("CassetteFromStorage() error: %v", err)
+	}
+	transport, err := NewReplayTransport(cassette, ReplayStrict)
+	if err != nil {
+		t.Fatalf("NewReplayTransport() error: %v", err)
+	}
+	client := NewClient(WithToken("other-token"), WithClient(&http.Client{Transport: transport}))
+
+	if status, err := client.Status(NewRequest().WithInvoiceID("inv-1")); err != nil || status.InvoiceID != "inv-1" {
+		t.Fatalf("replayed status = %+v, %v", status, err)
+	}
+	if payment, err := client.Payment(paymentRequest(100)); err != nil || payment.InvoiceID != "inv-2" {
+		t.Fatalf("replayed payment = %+v, %v", payment, err)
+	}
+	if _, err := client.Status(NewRequest().WithInvoiceID("missing")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected replayed 404, got %v", err)
+	}
+	if transport.Pending() != 0 {
+		t.Fatalf("pending = %d, want 0", transport.Pending())
+	}
+}
+
+func TestReplayTransportStrictEnforcesOrder(t *testing.T) {
+	t.Parallel()
+
+	path := recordTestCassette(t)
+
+	strict, _ := newReplayClient(t, path, ReplayStrict)
+	if _, err := strict.Payment(paymentRequest(100)); !errors.Is(err, ErrReplayMismatch) {
+		t.Fatalf("strict mode must serve interactions in recorded order, got %v", err)
+	}
+
+	lenient, _ := newReplayClient(t, path, ReplayLenient)
+	if payment, err := lenient.Payment(paymentRequest(100)); err != nil || payment.InvoiceID != "inv-2" {
+		t.Fatalf("lenient mode must allow reordered calls, got %+v, %v", payment, err)
+	}
+}
+
+func TestReplayTransportLenientIgnoresBody(t *testing.T) {
+	t.Parallel()
+
+	path := recordTestCassette(t)
+
+	strict, _ := newReplayClient(t, path, ReplayStrict)
+	if _, err := strict.Payment(paymentRequest(200)); !errors.Is(err, ErrReplayMismatch) {
+		t.Fatalf("strict mode must compare body, got %v", err)
+	}
+
+	lenient, _ := newReplayClient(t, path, ReplayLenient)
+	for i := 0; i < 2; i++ {
+		payment, err := lenient.Payment(paymentRequest(200))
+		if err != nil || payment.InvoiceID != "inv-2" {
+			t.Fatalf("lenient payment #%d = %+v, %v", i, payment, err)
+		}
+	}
+}
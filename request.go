@@ -1,6 +1,10 @@
 package go_monobank
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Request is a unified request object for common monobank acquiring flows.
 // It is intentionally similar to go-ipay/go-platon style (Merchant + PaymentData + PaymentMethod)
@@ -8,8 +12,16 @@ import "strings"
 //
 // This request is used by:
 //   - Verification / VerificationLink (invoice/create + saveCardData)
+//   - CreateInvoice (invoice/create)
 //   - Status (invoice/status)
+//   - Cancel / ReleaseHold (invoice/cancel)
+//   - Finalize (invoice/finalize)
+//   - Statement (statement)
+//   - QR cash registers (qr/list, qr/details, qr/reset-amount)
+//   - Merchant details, sub-merchants, employees (details, submerchant/list, employee/list)
+//   - Split receivers (split-receiver/list)
 //   - Payment (wallet/payment)
+//   - PaymentDirect (invoice/payment-direct)
 //   - PublicKey (pubkey)
 type Request struct {
 	Merchant      *Merchant
@@ -33,6 +45,31 @@ type PaymentData struct {
 	ValiditySeconds *int64
 	InitiationKind  InitiationKind
 
+	// ExtRef is a merchant-defined reference of a cancel operation.
+	ExtRef *string
+	// Items are basket items of a partial cancel/finalize operation.
+	Items []BasketItem
+	// HeldAmount is the amount returned by Hold; Finalize refuses to capture more.
+	HeldAmount int64
+
+	// QrID targets invoice to a QR cash register.
+	QrID *string
+	// Code is a sub-merchant code the invoice is created for.
+	Code *string
+	// AgentFeePercent is an agent fee percent (0..100).
+	AgentFeePercent *float64
+	// DisplayType controls how the payment page is rendered (e.g. iframe).
+	DisplayType DisplayType
+	// TipsEmployeeID is an employee identifier receiving tips.
+	TipsEmployeeID *string
+
+	// Splits distribute invoice amount between split receivers.
+	Splits []Split
+
+	// PeriodFrom/PeriodTo bound merchant statement period.
+	PeriodFrom *time.Time
+	PeriodTo   *time.Time
+
 	MerchantPaymInfo *MerchantPaymInfo
 }
 
@@ -40,6 +77,9 @@ type PaymentMethod struct {
 	CardToken *string
 	AToken    *string
 
+	// CardData is raw card data for PaymentDirect (PCI DSS-certified merchants only).
+	CardData *CardData
+
 	// ApplePayToken is the raw JSON string of ApplePayPaymentToken.
 	ApplePayToken *string
 	// ApplePayPayment is the raw JSON string of the full ApplePayPayment object.
@@ -168,6 +208,121 @@ func (r *Request) WithInitiationKind(kind InitiationKind) *Request {
 	return r
 }
 
+// WithQrID targets invoice to a QR cash register (qrId).
+func (r *Request) WithQrID(qrID string) *Request {
+	qrID = strings.TrimSpace(qrID)
+	if qrID == "" {
+		return r
+	}
+	r.ensurePaymentData().QrID = &qrID
+	return r
+}
+
+// WithCode sets sub-merchant code (code).
+func (r *Request) WithCode(code string) *Request {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return r
+	}
+	r.ensurePaymentData().Code = &code
+	return r
+}
+
+// WithAgentFeePercent sets agent fee percent (agentFeePercent).
+func (r *Request) WithAgentFeePercent(percent float64) *Request {
+	r.ensurePaymentData().AgentFeePercent = &percent
+	return r
+}
+
+// WithDisplayType sets payment page display type (displayType).
+func (r *Request) WithDisplayType(displayType DisplayType) *Request {
+	r.ensurePaymentData().DisplayType = displayType
+	return r
+}
+
+// WithTipsEmployeeID sets employee receiving tips (tipsEmployeeId).
+func (r *Request) WithTipsEmployeeID(employeeID string) *Request {
+	employeeID = strings.TrimSpace(employeeID)
+	if employeeID == "" {
+		return r
+	}
+	r.ensurePaymentData().TipsEmployeeID = &employeeID
+	return r
+}
+
+// WithSplit attaches a split receiver with its part of the amount (minor units).
+func (r *Request) WithSplit(splitReceiverID string, amountMinor int64) *Request {
+	splitReceiverID = strings.TrimSpace(splitReceiverID)
+	if splitReceiverID == "" {
+		return r
+	}
+	pd := r.ensurePaymentData()
+	pd.Splits = append(pd.Splits, Split{SplitReceiverID: splitReceiverID, Amount: amountMinor})
+	return r
+}
+
+// WithSplits replaces split receivers.
+func (r *Request) WithSplits(splits ...Split) *Request {
+	r.ensurePaymentData().Splits = append([]Split(nil), splits...)
+	return r
+}
+
+// WithPeriod sets merchant statement period. Zero "to" means now.
+func (r *Request) WithPeriod(from, to time.Time) *Request {
+	pd := r.ensurePaymentData()
+	if !from.IsZero() {
+		pd.PeriodFrom = &from
+	}
+	if !to.IsZero() {
+		pd.PeriodTo = &to
+	}
+	return r
+}
+
+// WithExtRef sets merchant reference of a cancel operation (extRef).
+func (r *Request) WithExtRef(extRef string) *Request {
+	extRef = strings.TrimSpace(extRef)
+	if extRef == "" {
+		return r
+	}
+	r.ensurePaymentData().ExtRef = &extRef
+	return r
+}
+
+// WithItems replaces basket items of a cancel/finalize operation.
+func (r *Request) WithItems(items ...BasketItem) *Request {
+	r.ensurePaymentData().Items = append([]BasketItem(nil), items...)
+	return r
+}
+
+// AddItem appends a basket item to a cancel/finalize operation.
+func (r *Request) AddItem(item BasketItem) *Request {
+	pd := r.ensurePaymentData()
+	pd.Items = append(pd.Items, item)
+	return r
+}
+
+// WithHeldAmount sets the amount held by Hold, used to validate Finalize amount.
+func (r *Request) WithHeldAmount(amountMinor int64) *Request {
+	r.ensurePaymentData().HeldAmount = amountMinor
+	return r
+}
+
+// ForHold prepares request to finalize or release a hold:
+// sets invoiceId, currency and held amount from Hold response.
+func (r *Request) ForHold(hold *WalletPaymentResponse) *Request {
+	if hold == nil {
+		return r
+	}
+	r.WithInvoiceID(hold.InvoiceID)
+	pd := r.ensurePaymentData()
+	pd.HeldAmount = hold.Amount
+	if pd.Currency == 0 {
+		pd.Currency = hold.Currency
+	}
+	return r
+}
+
 func (r *Request) WithMerchantPaymInfo(info *MerchantPaymInfo) *Request {
 	r.ensurePaymentData().MerchantPaymInfo = info
 	return r
@@ -208,6 +363,25 @@ func (r *Request) WithComment(comment string) *Request {
 	return r
 }
 
+// WithBasketOrder replaces merchantPaymInfo.basketOrder items.
+func (r *Request) WithBasketOrder(items ...BasketItem) *Request {
+	r.ensureMerchantPaymInfo().BasketOrder = append([]BasketItem(nil), items...)
+	return r
+}
+
+// AddBasketItem appends an item to merchantPaymInfo.basketOrder.
+func (r *Request) AddBasketItem(item BasketItem) *Request {
+	info := r.ensureMerchantPaymInfo()
+	info.BasketOrder = append(info.BasketOrder, item)
+	return r
+}
+
+// WithBasketDiscounts sets basket-level merchantPaymInfo.discounts.
+func (r *Request) WithBasketDiscounts(discounts ...Discount) *Request {
+	r.ensureMerchantPaymInfo().Discounts = append([]Discount(nil), discounts...)
+	return r
+}
+
 // SaveCard enables tokenization and sets walletId.
 func (r *Request) SaveCard(walletID string) *Request {
 	walletID = strings.TrimSpace(walletID)
@@ -251,6 +425,17 @@ func (r *Request) WithCardToken(cardToken string) *Request {
 	return r
 }
 
+// WithCardData sets raw card data for PaymentDirect.
+// exp is expiry in MMYY format.
+func (r *Request) WithCardData(pan, exp, cvv string) *Request {
+	r.ensurePaymentMethod().CardData = &CardData{
+		PAN: strings.TrimSpace(pan),
+		Exp: strings.TrimSpace(exp),
+		CVV: strings.TrimSpace(cvv),
+	}
+	return r
+}
+
 func (r *Request) WithAToken(aToken string) *Request {
 	aToken = strings.TrimSpace(aToken)
 	if aToken == "" {
@@ -314,6 +499,23 @@ func (r *Request) WithGoogleToken(payload string) *Request {
 	return r
 }
 
+// ValidateMerchantRefs checks that sub-merchant code and tipsEmployeeId set on request
+// exist in live data returned by SubMerchants / Employees.
+// Nil lists skip the corresponding check.
+func (r *Request) ValidateMerchantRefs(subMerchants *SubMerchantListResponse, employees *EmployeeListResponse) error {
+	if code := r.GetCode(); code != nil && subMerchants != nil {
+		if _, ok := subMerchants.FindByCode(*code); !ok {
+			return &ValidationError{Op: "merchantRefs", Msg: "unknown sub-merchant code " + strings.TrimSpace(*code)}
+		}
+	}
+	if employeeID := r.GetTipsEmployeeID(); employeeID != nil && employees != nil {
+		if _, ok := employees.FindByID(*employeeID); !ok {
+			return &ValidationError{Op: "merchantRefs", Msg: "unknown tipsEmployeeId " + strings.TrimSpace(*employeeID)}
+		}
+	}
+	return nil
+}
+
 // GetToken resolves X-Token from request.
 func (r *Request) GetToken() string {
 	if r == nil || r.Merchant == nil {
@@ -378,6 +580,146 @@ func (r *Request) GetInitiationKind() InitiationKind {
 	return r.PaymentData.InitiationKind
 }
 
+func (r *Request) GetQrID() *string {
+	if r == nil || r.PaymentData == nil {
+		return nil
+	}
+	return r.PaymentData.QrID
+}
+
+// GetQrIDValue returns trimmed qrId or empty string.
+func (r *Request) GetQrIDValue() string {
+	if qrID := r.GetQrID(); qrID != nil {
+		return strings.TrimSpace(*qrID)
+	}
+	return ""
+}
+
+func (r *Request) GetCode() *string {
+	if r == nil || r.PaymentData == nil {
+		return nil
+	}
+	return r.PaymentData.Code
+}
+
+func (r *Request) GetAgentFeePercent() *float64 {
+	if r == nil || r.PaymentData == nil {
+		return nil
+	}
+	return r.PaymentData.AgentFeePercent
+}
+
+func (r *Request) GetDisplayType() DisplayType {
+	if r == nil || r.PaymentData == nil {
+		return ""
+	}
+	return r.PaymentData.DisplayType
+}
+
+func (r *Request) GetTipsEmployeeID() *string {
+	if r == nil || r.PaymentData == nil {
+		return nil
+	}
+	return r.PaymentData.TipsEmployeeID
+}
+
+func (r *Request) GetSplits() []Split {
+	if r == nil || r.PaymentData == nil {
+		return nil
+	}
+	return r.PaymentData.Splits
+}
+
+// ValidateSplits checks split receivers and verifies that split amounts sum to PaymentData.Amount.
+// Receivers of basketOrder items (BasketItem.SplitReceiverID) are checked too.
+// Requests without splits are always valid. Nil receivers list skips receiver existence check.
+func (r *Request) ValidateSplits(receivers *SplitReceiverListResponse) error {
+	if err := r.validateSplits(receivers); err != nil {
+		return &ValidationError{Op: "splits", Msg: err.Error(), Cause: err}
+	}
+	return nil
+}
+
+func (r *Request) validateSplits(receivers *SplitReceiverListResponse) error {
+	knownReceiver := func(id string) bool {
+		if receivers == nil {
+			return true
+		}
+		_, ok := receivers.FindByID(id)
+		return ok
+	}
+
+	if info := r.GetMerchantPaymInfo(); info != nil {
+		for i, item := range info.BasketOrder {
+			if id := item.SplitReceiverID; id != "" && !knownReceiver(id) {
+				return fmt.Errorf("basketOrder items[%d]: unknown splitReceiverId %s", i, id)
+			}
+		}
+	}
+	for i, item := range r.GetItems() {
+		if id := item.SplitReceiverID; id != "" && !knownReceiver(id) {
+			return fmt.Errorf("items[%d]: unknown splitReceiverId %s", i, id)
+		}
+	}
+
+	splits := r.GetSplits()
+	if len(splits) == 0 {
+		return nil
+	}
+	var total int64
+	for i, split := range splits {
+		if strings.TrimSpace(split.SplitReceiverID) == "" {
+			return fmt.Errorf("splits[%d].splitReceiverId is required", i)
+		}
+		if split.Amount <= 0 {
+			return fmt.Errorf("splits[%d].amount must be > 0", i)
+		}
+		if !knownReceiver(split.SplitReceiverID) {
+			return fmt.Errorf("unknown splitReceiverId %s", split.SplitReceiverID)
+		}
+		total += split.Amount
+	}
+	if amount := r.GetAmount(); total != amount {
+		return fmt.Errorf("split amounts sum %d does not match amount %d", total, amount)
+	}
+	return nil
+}
+
+func (r *Request) GetPeriodFrom() time.Time {
+	if r == nil || r.PaymentData == nil || r.PaymentData.PeriodFrom == nil {
+		return time.Time{}
+	}
+	return *r.PaymentData.PeriodFrom
+}
+
+func (r *Request) GetPeriodTo() time.Time {
+	if r == nil || r.PaymentData == nil || r.PaymentData.PeriodTo == nil {
+		return time.Time{}
+	}
+	return *r.PaymentData.PeriodTo
+}
+
+func (r *Request) GetExtRef() string {
+	if r == nil || r.PaymentData == nil || r.PaymentData.ExtRef == nil {
+		return ""
+	}
+	return strings.TrimSpace(*r.PaymentData.ExtRef)
+}
+
+func (r *Request) GetItems() []BasketItem {
+	if r == nil || r.PaymentData == nil {
+		return nil
+	}
+	return r.PaymentData.Items
+}
+
+func (r *Request) GetHeldAmount() int64 {
+	if r == nil || r.PaymentData == nil {
+		return 0
+	}
+	return r.PaymentData.HeldAmount
+}
+
 func (r *Request) GetMerchantPaymInfo() *MerchantPaymInfo {
 	if r == nil || r.PaymentData == nil {
 		return nil
@@ -392,6 +734,13 @@ func (r *Request) GetCardToken() string {
 	return strings.TrimSpace(*r.PaymentMethod.CardToken)
 }
 
+func (r *Request) GetCardData() *CardData {
+	if r == nil || r.PaymentMethod == nil {
+		return nil
+	}
+	return r.PaymentMethod.CardData
+}
+
 func (r *Request) GetWalletID() string {
 	if r == nil || r.PaymentMethod == nil || r.PaymentMethod.WalletID == nil {
 		return ""
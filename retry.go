@@ -0,0 +1,138 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 200 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+	defaultRetryMaxRetryAfter  = 30 * time.Second
+	defaultRetryMultiplier     = 2.0
+	defaultRetryJitter         = 0.2
+)
+
+// RetryPolicy controls automatic retries of failed API calls.
+//
+// By default only idempotent GET calls (Status, Wallet, FiscalChecks, PublicKey, ...)
+// are retried. POST/DELETE calls such as Payment are retried only when
+// RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential delay.
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest Retry-After from API the client waits for.
+	// When API asks to wait longer, the APIError is returned without retrying.
+	MaxRetryAfter time.Duration
+	// Multiplier grows the delay after every attempt.
+	Multiplier float64
+	// Jitter randomizes every delay by ±Jitter fraction (0..1). Zero means the default 0.2;
+	// negative value disables jitter.
+	Jitter float64
+	// Retryable reports whether err is worth another attempt. Defaults to IsRetryableError.
+	Retryable func(err error) bool
+	// RetryNonIdempotent enables retries for POST/DELETE calls (Payment, Hold, Cancel, ...).
+	// Enable it only when a repeated request cannot charge twice for your integration.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns policy with 3 attempts, 200ms..5s exponential backoff
+// and Retry-After honored up to 30s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    defaultRetryMaxAttempts,
+		InitialBackoff: defaultRetryInitialBackoff,
+		MaxBackoff:     defaultRetryMaxBackoff,
+		MaxRetryAfter:  defaultRetryMaxRetryAfter,
+		Multiplier:     defaultRetryMultiplier,
+		Jitter:         defaultRetryJitter,
+	}
+}
+
+// IsRetryableError reports whether err is a transport error, HTTP 429 or HTTP 5xx.
+func IsRetryableError(err error) bool {
+	return errors.Is(err, ErrTransport) || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServerError)
+}
+
+func (p RetryPolicy) normalized() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultRetryMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultRetryInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaultRetryMaxBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+	if p.MaxRetryAfter <= 0 {
+		p.MaxRetryAfter = defaultRetryMaxRetryAfter
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaultRetryMultiplier
+	}
+	// Negative Jitter is kept as is (disabled), so normalizing twice is safe.
+	if p.Jitter == 0 {
+		p.Jitter = defaultRetryJitter
+	}
+	if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	if p.Retryable == nil {
+		p.Retryable = IsRetryableError
+	}
+	return p
+}
+
+func (p *RetryPolicy) allows(method string) bool {
+	if p == nil || p.MaxAttempts < 2 {
+		return false
+	}
+	return method == http.MethodGet || p.RetryNonIdempotent
+}
+
+// delay returns wait duration before attempt+1. Retry-After from APIError wins over backoff;
+// ok is false when it exceeds MaxRetryAfter and the call must not be retried.
+func (p *RetryPolicy) delay(attempt int, err error) (d time.Duration, ok bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter != nil && *apiErr.RetryAfter > 0 {
+		if *apiErr.RetryAfter > p.MaxRetryAfter {
+			return *apiErr.RetryAfter, false
+		}
+		return *apiErr.RetryAfter, true
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(backoff), true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
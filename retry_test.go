@@ -0,0 +1,232 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stremovskyy/recorder"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+		Jitter:         -1,
+	}
+}
+
+func TestStatusRetriesServerErrorsUntilSuccess(t *testing.T) {
+	storage := &captureStorage{}
+	var calls atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) < 3 {
+					w.WriteHeader(http.StatusBadGateway)
+					_, _ = w.Write([]byte(`{"errCode":"BAD_GATEWAY","errText":"upstream"}`))
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"invoiceId":"inv-1","status":"success","amount":100,"ccy":980}`))
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithToken("merchant-token"),
+		WithRecorder(recorder.New(storage)),
+		WithRetryPolicy(fastRetryPolicy()),
+	)
+
+	resp, err := client.Status(NewRequest().WithInvoiceID("inv-1"))
+	if err != nil {
+		t.Fatalf("Status() unexpected error: %v", err)
+	}
+	if resp.Status != InvoiceSuccess {
+		t.Fatalf("status = %s, want success", resp.Status)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("calls = %d, want 3", got)
+	}
+
+	requests := 0
+	lastAttempt := ""
+	for _, record := range storage.snapshot() {
+		if record.Type == recorder.RecordTypeRequest {
+			requests++
+			lastAttempt = record.Tags["attempt"]
+		}
+	}
+	if requests != 3 {
+		t.Fatalf("recorded requests = %d, want 3", requests)
+	}
+	if lastAttempt != "3" {
+		t.Fatalf("last attempt tag = %q, want 3", lastAttempt)
+	}
+}
+
+func TestPaymentIsNotRetriedWithoutOptIn(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+		),
+	)
+	defer server.Close()
+
+	request := func() *Request {
+		return NewRequest().
+			WithCardToken("card-token").
+			WithAmount(100).
+			WithInitiationKind(InitiationMerchant)
+	}
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"), WithRetryPolicy(fastRetryPolicy()))
+	if _, err := client.Payment(request()); !errors.Is(err, ErrServerError) {
+		t.Fatalf("expected ErrServerError, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("calls without opt-in = %d, want 1", got)
+	}
+
+	calls.Store(0)
+	policy := fastRetryPolicy()
+	policy.RetryNonIdempotent = true
+	client = NewClient(WithBaseURL(server.URL), WithToken("merchant-token"), WithRetryPolicy(policy))
+	if _, err := client.Payment(request()); !errors.Is(err, ErrServerError) {
+		t.Fatalf("expected ErrServerError, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("calls with opt-in = %d, want 3", got)
+	}
+}
+
+func TestRetryDoesNotRepeatClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(http.StatusBadRequest)
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"), WithRetryPolicy(fastRetryPolicy()))
+	if _, err := client.Status(NewRequest().WithInvoiceID("inv-1")); !errors.Is(err, ErrBadRequest) {
+		t.Fatalf("expected ErrBadRequest, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+}
+
+func TestRetryHonorsRetryAfterAndContext(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.Header().Set("Retry-After", "60")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"), WithRetryPolicy(fastRetryPolicy()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err := client.StatusContext(ctx, NewRequest().WithInvoiceID("inv-1"))
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("retry wait must stop on context deadline, took %s", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1 (Retry-After exceeds deadline)", got)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Jitter: -1}.normalized()
+
+	cases := map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 4: 300 * time.Millisecond}
+	for attempt, want := range cases {
+		if got, ok := policy.delay(attempt, errors.New("boom")); got != want || !ok {
+			t.Fatalf("delay(%d) = %s, %v, want %s", attempt, got, ok, want)
+		}
+	}
+
+	retryAfter := 7 * time.Second
+	if got, ok := policy.delay(1, &APIError{Kind: ErrRateLimited, RetryAfter: &retryAfter}); got != retryAfter || !ok {
+		t.Fatalf("delay with Retry-After = %s, %v, want %s", got, ok, retryAfter)
+	}
+
+	if got := (RetryPolicy{}).normalized().Jitter; got != defaultRetryJitter {
+		t.Fatalf("zero Jitter must default to %v, got %v", defaultRetryJitter, got)
+	}
+	if got := policy.normalized().Jitter; got >= 0 {
+		t.Fatalf("negative Jitter must stay disabled, got %v", got)
+	}
+
+	retryAfter = time.Hour
+	if _, ok := policy.delay(1, &APIError{Kind: ErrRateLimited, RetryAfter: &retryAfter}); ok {
+		t.Fatalf("Retry-After above MaxRetryAfter must not be retried")
+	}
+}
+
+func TestRetryGivesUpOnLongRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.Header().Set("Retry-After", "3600")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"), WithRetryPolicy(fastRetryPolicy()))
+
+	started := time.Now()
+	_, err := client.Status(NewRequest().WithInvoiceID("inv-1"))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter == nil || *apiErr.RetryAfter != time.Hour {
+		t.Fatalf("expected APIError with Retry-After, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("client must not wait for long Retry-After, took %s", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+}
@@ -15,21 +15,26 @@ type DryRunHandler func(endpoint string, payload any)
 type runOptions struct {
 	dryRun       bool
 	dryRunHandle DryRunHandler
+	// dryRunPrint is set when DryRun prints payload itself; printed payload is redacted.
+	dryRunPrint bool
 }
 
 var dryRunLogger = log.NewLogger("Monobank DryRun:")
 
 // DryRun skips the underlying HTTP call.
 //
-// Optional handler can be provided to inspect payload.
+// Optional handler can be provided to inspect payload. It receives the request payload as
+// is (typed, not redacted), so do not log it; without a handler payload is printed redacted.
 func DryRun(handler ...DryRunHandler) RunOption {
 	return func(o *runOptions) {
 		o.dryRun = true
 		if len(handler) > 0 && handler[0] != nil {
 			o.dryRunHandle = handler[0]
+			o.dryRunPrint = false
 			return
 		}
 		o.dryRunHandle = defaultDryRunHandler
+		o.dryRunPrint = true
 	}
 }
 
@@ -50,6 +55,10 @@ func (o *runOptions) isDryRun() bool {
 	return o != nil && o.dryRun
 }
 
+func (o *runOptions) usesDefaultDryRunHandler() bool {
+	return o != nil && o.dryRun && o.dryRunPrint
+}
+
 func (o *runOptions) handleDryRun(endpoint string, payload any) {
 	if o == nil || !o.dryRun {
 		return
@@ -0,0 +1,134 @@
+package go_monobank
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateSplits(t *testing.T) {
+	t.Parallel()
+
+	receivers := &SplitReceiverListResponse{List: []SplitReceiver{{SplitReceiverID: "r-1"}, {SplitReceiverID: "r-2"}}}
+	tests := []struct {
+		name    string
+		request *Request
+		wantErr bool
+	}{
+		{name: "no splits", request: NewRequest().WithAmount(100)},
+		{name: "matching sum", request: NewRequest().WithAmount(100).WithSplit("r-1", 70).WithSplit("r-2", 30)},
+		{name: "mismatched sum", request: NewRequest().WithAmount(100).WithSplit("r-1", 70), wantErr: true},
+		{name: "non-positive amount", request: NewRequest().WithAmount(0).WithSplit("r-1", 0), wantErr: true},
+		{name: "unknown receiver", request: NewRequest().WithAmount(100).WithSplit("r-3", 100), wantErr: true},
+		{
+			name:    "known item receiver",
+			request: NewRequest().WithAmount(100).WithBasketOrder(BasketItem{Name: "a", Qty: 1, Sum: 100, Code: "a", SplitReceiverID: "r-1"}),
+		},
+		{
+			name:    "unknown item receiver",
+			request: NewRequest().WithAmount(100).WithBasketOrder(BasketItem{Name: "a", Qty: 1, Sum: 100, Code: "a", SplitReceiverID: "r-3"}),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(
+			tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				err := tc.request.ValidateSplits(receivers)
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("ValidateSplits() error = %v, wantErr %v", err, tc.wantErr)
+				}
+				if err != nil && !errors.Is(err, ErrValidation) {
+					t.Fatalf("expected ErrValidation, got %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestSplitsAreSentWithInvoiceAndPayment(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+
+	var invoicePayload, paymentPayload any
+	_, err := client.CreateInvoice(
+		NewRequest().WithAmount(100).WithSplit("r-1", 60).WithSplit("r-2", 40),
+		DryRun(func(_ string, p any) { invoicePayload = p }),
+	)
+	if err != nil {
+		t.Fatalf("CreateInvoice() unexpected error: %v", err)
+	}
+	_, err = client.Payment(
+		NewRequest().WithCardToken("card-token").WithInitiationKind(InitiationMerchant).WithAmount(100).WithSplit("r-1", 100),
+		DryRun(func(_ string, p any) { paymentPayload = p }),
+	)
+	if err != nil {
+		t.Fatalf("Payment() unexpected error: %v", err)
+	}
+
+	for name, payload := range map[string]any{"invoice": invoicePayload, "payment": paymentPayload} {
+		payloadJSON, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("%s: marshal dry-run payload: %v", name, err)
+		}
+		var got struct {
+			Splits []Split `json:"splits"`
+		}
+		if err = json.Unmarshal(payloadJSON, &got); err != nil {
+			t.Fatalf("%s: unmarshal dry-run payload: %v", name, err)
+		}
+		if len(got.Splits) == 0 || got.Splits[0].SplitReceiverID != "r-1" {
+			t.Fatalf("%s: unexpected splits: %s", name, payloadJSON)
+		}
+	}
+
+	_, err = client.Verification(NewRequest().WithAmount(100).WithSplit("r-1", 99), DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation for mismatched splits, got %v", err)
+	}
+}
+
+func TestSplitReceiversDecodesList(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/merchant/split-receiver/list" {
+					t.Fatalf("unexpected path: %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"list":[{"splitReceiverId":"r-1","name":"Seller"}]}`))
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	receivers, err := client.SplitReceivers(nil)
+	if err != nil {
+		t.Fatalf("SplitReceivers() unexpected error: %v", err)
+	}
+	if receiver, ok := receivers.FindByID("r-1"); !ok || receiver.Name != "Seller" {
+		t.Fatalf("unexpected receivers: %+v", receivers)
+	}
+}
+
+func TestSplitErrorsUseCallerOp(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	_, err := client.CreateInvoice(NewRequest().WithAmount(100).WithSplit("r-1", 50), DryRun())
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Op != "createInvoice" {
+		t.Fatalf("expected ValidationError with op createInvoice, got %v", err)
+	}
+}
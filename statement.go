@@ -0,0 +1,79 @@
+package go_monobank
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// StatementMaxWindow is the longest period monobank returns in one statement call.
+const StatementMaxWindow = 31 * 24 * time.Hour
+
+// StatementAll iterates over statement items of [from, to], issuing one
+// Statement call per StatementMaxWindow. Iteration stops on the first error,
+// which is yielded together with a zero StatementItem.
+func (c *client) StatementAll(ctx context.Context, request *Request, runOpts ...RunOption) iter.Seq2[StatementItem, error] {
+	return func(yield func(StatementItem, error) bool) {
+		from, to, err := resolveStatementPeriod(request)
+		if err != nil {
+			yield(StatementItem{}, &ValidationError{Op: "statement", Msg: err.Error()})
+			return
+		}
+
+		for _, window := range statementWindows(from, to, StatementMaxWindow) {
+			windowRequest := *request
+			if request.PaymentData != nil {
+				pd := *request.PaymentData
+				windowRequest.PaymentData = &pd
+			}
+			windowRequest.WithPeriod(window[0], window[1])
+
+			resp, err := c.StatementContext(ctx, &windowRequest, runOpts...)
+			if err != nil {
+				yield(StatementItem{}, err)
+				return
+			}
+			if resp == nil {
+				// dry run
+				continue
+			}
+			for _, item := range resp.List {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func resolveStatementPeriod(r *Request) (time.Time, time.Time, error) {
+	from := r.GetPeriodFrom()
+	if from.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("period start is required (set request.WithPeriod(...))")
+	}
+	to := r.GetPeriodTo()
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("period start must be before period end")
+	}
+	return from, to, nil
+}
+
+// statementWindows splits [from, to] into windows not longer than max. monobank treats
+// from/to as inclusive (in seconds), so each window starts one second after the previous
+// one ends and boundary items are not returned twice.
+func statementWindows(from, to time.Time, max time.Duration) [][2]time.Time {
+	var out [][2]time.Time
+	for start := from; start.Before(to); {
+		end := start.Add(max)
+		if end.After(to) {
+			end = to
+		}
+		out = append(out, [2]time.Time{start, end})
+		start = end.Add(time.Second)
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStatementRejectsTooLongPeriod(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := client.Statement(NewRequest().WithPeriod(from, from.Add(StatementMaxWindow+time.Second)), DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestStatementWindows(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(70 * 24 * time.Hour)
+
+	windows := statementWindows(from, to, StatementMaxWindow)
+	if len(windows) != 3 {
+		t.Fatalf("expected 3 windows, got %d", len(windows))
+	}
+	if !windows[0][0].Equal(from) || !windows[2][1].Equal(to) {
+		t.Fatalf("windows must cover whole period: %v", windows)
+	}
+	for i := 1; i < len(windows); i++ {
+		if !windows[i][0].Equal(windows[i-1][1].Add(time.Second)) {
+			t.Fatalf("windows must not overlap: %v", windows)
+		}
+	}
+}
+
+func TestStatementAllSplitsPeriodAndDecodesItems(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var calls [][2]int64
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/merchant/statement" {
+					t.Fatalf("unexpected path: %s", r.URL.Path)
+				}
+				if r.URL.Query().Get("code") != "shop-1" {
+					t.Fatalf("unexpected code: %s", r.URL.RawQuery)
+				}
+				from, _ := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+				to, _ := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+
+				mu.Lock()
+				calls = append(calls, [2]int64{from, to})
+				n := len(calls)
+				mu.Unlock()
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(
+					w,
+					`{"list":[{"invoiceId":"inv-%d","status":"success","maskedPan":"444403******1902","date":"2026-01-02T10:00:00Z","paymentScheme":"full","amount":4200,"profitAmount":4100,"ccy":980,"rrn":"060189181768","shortQrId":"OBJE","cancelList":[{"amount":100,"ccy":980,"date":"2026-01-03T10:00:00Z"}]}]}`,
+					n,
+				)
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(40 * 24 * time.Hour)
+
+	var items []StatementItem
+	for item, err := range client.StatementAll(context.Background(), NewRequest().WithPeriod(from, to).WithCode("shop-1")) {
+		if err != nil {
+			t.Fatalf("StatementAll() unexpected error: %v", err)
+		}
+		items = append(items, item)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 statement calls, got %d", len(calls))
+	}
+	if calls[0][0] != from.Unix() || calls[1][1] != to.Unix() || calls[1][0] != calls[0][1]+1 {
+		t.Fatalf("unexpected windows: %v", calls)
+	}
+
+	if len(items) != 2 || items[0].InvoiceID != "inv-1" || items[1].InvoiceID != "inv-2" {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	item := items[0]
+	if item.ProfitAmount == nil || *item.ProfitAmount != 4100 || item.ShortQrID == nil || *item.ShortQrID != "OBJE" {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+	if len(item.CancelList) != 1 || item.CancelList[0].Date == nil {
+		t.Fatalf("unexpected cancelList: %+v", item.CancelList)
+	}
+}
+
+func TestStatementAllStopsOnError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errCode":"FORBIDDEN","errText":"invalid token"}`))
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var errs []error
+	for _, err := range client.StatementAll(context.Background(), NewRequest().WithPeriod(from, from.Add(90*24*time.Hour))) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrInvalidToken) {
+		t.Fatalf("expected single ErrInvalidToken, got %v", errs)
+	}
+}
@@ -28,6 +28,15 @@ const (
 	PaymentTypeVerification PaymentType = "verification"
 )
 
+// DisplayType defines how the hosted payment page is rendered.
+//
+// iframe - payment page is embedded into merchant page
+type DisplayType string
+
+const (
+	DisplayTypeIframe DisplayType = "iframe"
+)
+
 // InitiationKind defines who initiated the wallet/token payment.
 //
 // merchant - merchant-initiated (recurring, etc)
@@ -50,6 +59,9 @@ const (
 	InvoiceFailure    InvoiceStatus = "failure"
 	InvoiceReversed   InvoiceStatus = "reversed"
 	InvoiceExpired    InvoiceStatus = "expired"
+
+	// InvoiceHold means funds are held and wait for Finalize or ReleaseHold.
+	InvoiceHold InvoiceStatus = "hold"
 )
 
 // IsSuccess reports whether status indicates successful payment completion.
@@ -64,7 +76,7 @@ func (s InvoiceStatus) IsFailure() bool {
 
 // IsPending reports whether status indicates non-final in-progress state.
 func (s InvoiceStatus) IsPending() bool {
-	return s == InvoiceCreated || s == InvoiceProcessing
+	return s == InvoiceCreated || s == InvoiceProcessing || s == InvoiceHold
 }
 
 // IsFinal reports whether status is final (success or non-success terminal).
@@ -72,7 +84,7 @@ func (s InvoiceStatus) IsFinal() bool {
 	return s.IsSuccess() || s.IsFailure()
 }
 
-// MerchantPaymInfo mirrors docs "merchantPaymInfo" (minimal subset).
+// MerchantPaymInfo mirrors docs "merchantPaymInfo".
 // You can extend it later without breaking callers.
 type MerchantPaymInfo struct {
 	Reference      string   `json:"reference,omitempty"`
@@ -80,8 +92,36 @@ type MerchantPaymInfo struct {
 	Comment        string   `json:"comment,omitempty"`
 	CustomerEmails []string `json:"customerEmails,omitempty"`
 
-	// NOTE: docs contain more fields (discounts, basketOrder, etc).
-	// They are intentionally omitted from the minimal SDK.
+	// Discounts are applied to the whole basket.
+	Discounts []Discount `json:"discounts,omitempty"`
+	// BasketOrder is an itemized basket, required for PRRO fiscalization.
+	BasketOrder []BasketItem `json:"basketOrder,omitempty"`
+}
+
+// DiscountType defines whether a Discount decreases or increases the price.
+type DiscountType string
+
+const (
+	DiscountTypeDiscount    DiscountType = "DISCOUNT"
+	DiscountTypeExtraCharge DiscountType = "EXTRA_CHARGE"
+)
+
+// DiscountMode defines how Discount.Value is interpreted.
+//
+// PERCENT - value is a percent of the price
+// VALUE   - value is an absolute amount in minor units
+type DiscountMode string
+
+const (
+	DiscountModePercent DiscountMode = "PERCENT"
+	DiscountModeValue   DiscountMode = "VALUE"
+)
+
+// Discount is a discount or extra charge applied to a basket item or the whole basket.
+type Discount struct {
+	Type  DiscountType `json:"type"`
+	Mode  DiscountMode `json:"mode"`
+	Value float64      `json:"value"`
 }
 
 type SaveCardData struct {
@@ -89,6 +129,28 @@ type SaveCardData struct {
 	WalletID string `json:"walletId,omitempty"`
 }
 
+// BasketItem is one product line (docs "items"/"basketOrder" entry).
+// Sum is the price of one unit in minor units; Qty may be fractional.
+// Total is an optional line total in minor units; when empty it is Sum*Qty with Discounts applied.
+type BasketItem struct {
+	Name      string     `json:"name"`
+	Qty       float64    `json:"qty"`
+	Sum       int64      `json:"sum"`
+	Total     int64      `json:"total,omitempty"`
+	Code      string     `json:"code"`
+	Barcode   string     `json:"barcode,omitempty"`
+	Header    string     `json:"header,omitempty"`
+	Footer    string     `json:"footer,omitempty"`
+	Tax       []int      `json:"tax,omitempty"`
+	Uktzed    string     `json:"uktzed,omitempty"`
+	Unit      string     `json:"unit,omitempty"`
+	Icon      string     `json:"icon,omitempty"`
+	Discounts []Discount `json:"discounts,omitempty"`
+
+	// SplitReceiverID routes this item to a split receiver.
+	SplitReceiverID string `json:"splitReceiverId,omitempty"`
+}
+
 // InvoiceCreateResponse is returned by POST /api/merchant/invoice/create.
 type InvoiceCreateResponse struct {
 	InvoiceID string `json:"invoiceId"`
@@ -122,8 +184,8 @@ func (r *InvoiceCreateResponse) ParsedPageURL() (*url.URL, error) {
 	return parsed, nil
 }
 
-// WalletPaymentResponse is returned by POST /api/merchant/wallet/payment.
-// Also resembles some other payment-related responses.
+// WalletPaymentResponse is returned by POST /api/merchant/wallet/payment
+// and POST /api/merchant/invoice/payment-direct.
 type WalletPaymentResponse struct {
 	InvoiceID     string        `json:"invoiceId"`
 	TDSURL        *string       `json:"tdsUrl,omitempty"`
@@ -346,17 +408,242 @@ type CancelItem struct {
 	ExtRef       *string `json:"extRef,omitempty"`
 
 	MaskedPan *string `json:"maskedPan,omitempty"`
+
+	// Date is set instead of Created/ModifiedDate in statement cancelList entries.
+	Date *time.Time `json:"date,omitempty"`
+}
+
+// CancelResponse is returned by POST /api/merchant/invoice/cancel.
+type CancelResponse struct {
+	Status       InvoiceStatus `json:"status"`
+	CreatedDate  time.Time     `json:"createdDate"`
+	ModifiedDate time.Time     `json:"modifiedDate"`
+}
+
+// IsSuccess reports whether cancellation has completed successfully.
+func (r *CancelResponse) IsSuccess() bool {
+	return r != nil && r.Status.IsSuccess()
 }
 
+// IsFailure reports whether cancellation has failed.
+func (r *CancelResponse) IsFailure() bool {
+	return r != nil && r.Status.IsFailure()
+}
+
+// IsPending reports whether cancellation is still processing.
+func (r *CancelResponse) IsPending() bool {
+	return r != nil && r.Status.IsPending()
+}
+
+// IsFinal reports whether cancellation status is final.
+func (r *CancelResponse) IsFinal() bool {
+	return r != nil && r.Status.IsFinal()
+}
+
+// CancelItem converts the response into the shape used by InvoiceStatusResponse.CancelList.
+// Cancel endpoint does not echo amount/extRef, so they are taken from arguments.
+func (r *CancelResponse) CancelItem(amount int64, ccy CurrencyCode, extRef string) CancelItem {
+	if r == nil {
+		return CancelItem{}
+	}
+	item := CancelItem{
+		Status:       r.Status,
+		Amount:       amount,
+		Currency:     ccy,
+		CreatedDate:  r.CreatedDate,
+		ModifiedDate: r.ModifiedDate,
+	}
+	if extRef = strings.TrimSpace(extRef); extRef != "" {
+		item.ExtRef = &extRef
+	}
+	return item
+}
+
+// FinalizeResponse is returned by POST /api/merchant/invoice/finalize.
+type FinalizeResponse struct {
+	Status InvoiceStatus `json:"status"`
+}
+
+// IsSuccess reports whether hold finalization has completed successfully.
+func (r *FinalizeResponse) IsSuccess() bool {
+	return r != nil && r.Status.IsSuccess()
+}
+
+// StatementResponse is returned by GET /api/merchant/statement.
+type StatementResponse struct {
+	List []StatementItem `json:"list"`
+}
+
+// StatementItem is one settled operation from merchant statement.
+type StatementItem struct {
+	InvoiceID     string        `json:"invoiceId"`
+	Status        InvoiceStatus `json:"status"`
+	MaskedPan     string        `json:"maskedPan"`
+	Date          time.Time     `json:"date"`
+	PaymentScheme string        `json:"paymentScheme"`
+	Amount        int64         `json:"amount"`
+	ProfitAmount  *int64        `json:"profitAmount,omitempty"`
+	Currency      CurrencyCode  `json:"ccy"`
+
+	ApprovalCode *string `json:"approvalCode,omitempty"`
+	RRN          *string `json:"rrn,omitempty"`
+	Reference    *string `json:"reference,omitempty"`
+	ShortQrID    *string `json:"shortQrId,omitempty"`
+
+	CancelList []CancelItem `json:"cancelList,omitempty"`
+}
+
+// MerchantDetailsResponse is returned by GET /api/merchant/details.
+type MerchantDetailsResponse struct {
+	MerchantID   string `json:"merchantId"`
+	MerchantName string `json:"merchantName"`
+	EDRPOU       string `json:"edrpou"`
+}
+
+// SubMerchantListResponse is returned by GET /api/merchant/submerchant/list.
+type SubMerchantListResponse struct {
+	List []SubMerchant `json:"list"`
+}
+
+// SubMerchant is a shop operating under the merchant token.
+// Code is used as invoice "code".
+type SubMerchant struct {
+	Code   string `json:"code"`
+	EDRPOU string `json:"edrpou,omitempty"`
+	IBAN   string `json:"iban,omitempty"`
+}
+
+// FindByCode returns sub-merchant by code and true if present.
+func (r *SubMerchantListResponse) FindByCode(code string) (*SubMerchant, bool) {
+	code = strings.TrimSpace(code)
+	if r == nil || code == "" {
+		return nil, false
+	}
+	for i := range r.List {
+		if r.List[i].Code == code {
+			return &r.List[i], true
+		}
+	}
+	return nil, false
+}
+
+// EmployeeListResponse is returned by GET /api/merchant/employee/list.
+type EmployeeListResponse struct {
+	List []Employee `json:"list"`
+}
+
+// Employee is a merchant employee that can receive tips.
+// ID is used as invoice "tipsEmployeeId".
+type Employee struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	ExtRef string `json:"extRef,omitempty"`
+}
+
+// FindByID returns employee by id and true if present.
+func (r *EmployeeListResponse) FindByID(id string) (*Employee, bool) {
+	id = strings.TrimSpace(id)
+	if r == nil || id == "" {
+		return nil, false
+	}
+	for i := range r.List {
+		if r.List[i].ID == id {
+			return &r.List[i], true
+		}
+	}
+	return nil, false
+}
+
+// SplitReceiverListResponse is returned by GET /api/merchant/split-receiver/list.
+type SplitReceiverListResponse struct {
+	List []SplitReceiver `json:"list"`
+}
+
+// SplitReceiver is a marketplace payout receiver.
+type SplitReceiver struct {
+	SplitReceiverID string `json:"splitReceiverId"`
+	Name            string `json:"name"`
+}
+
+// FindByID returns split receiver by id and true if present.
+func (r *SplitReceiverListResponse) FindByID(id string) (*SplitReceiver, bool) {
+	id = strings.TrimSpace(id)
+	if r == nil || id == "" {
+		return nil, false
+	}
+	for i := range r.List {
+		if r.List[i].SplitReceiverID == id {
+			return &r.List[i], true
+		}
+	}
+	return nil, false
+}
+
+// Split is a part of invoice amount paid out to a split receiver.
+type Split struct {
+	SplitReceiverID string `json:"splitReceiverId"`
+	Amount          int64  `json:"amount"`
+}
+
+// QRAmountType defines who sets the amount of a QR cash register payment.
+//
+// merchant - amount is pushed by merchant (CreateInvoice with qrId)
+// client   - amount is entered by customer
+// fix      - amount is fixed in QR settings
+type QRAmountType string
+
+const (
+	QRAmountMerchant QRAmountType = "merchant"
+	QRAmountClient   QRAmountType = "client"
+	QRAmountFix      QRAmountType = "fix"
+)
+
+// QRListResponse is returned by GET /api/merchant/qr/list.
+type QRListResponse struct {
+	List []QRItem `json:"list"`
+}
+
+// QRItem is a QR cash register.
+type QRItem struct {
+	ShortQrID  string       `json:"shortQrId"`
+	QrID       string       `json:"qrId"`
+	AmountType QRAmountType `json:"amountType"`
+	PageURL    string       `json:"pageUrl"`
+}
+
+// QRDetailsResponse is returned by GET /api/merchant/qr/details.
+// InvoiceID/Amount are set only while an amount is pushed onto the QR.
+type QRDetailsResponse struct {
+	ShortQrID string        `json:"shortQrId"`
+	InvoiceID *string       `json:"invoiceId,omitempty"`
+	Amount    *int64        `json:"amount,omitempty"`
+	Currency  *CurrencyCode `json:"ccy,omitempty"`
+}
+
+// HasAmount reports whether an amount is currently pushed onto the QR.
+func (r *QRDetailsResponse) HasAmount() bool {
+	return r != nil && r.Amount != nil && *r.Amount > 0
+}
+
+// PaymentInfo is card/terminal details of a paid invoice.
+// It is embedded into InvoiceStatusResponse and returned by GET /api/merchant/invoice/payment-info.
 type PaymentInfo struct {
 	MaskedPan     *string `json:"maskedPan,omitempty"`
 	ApprovalCode  *string `json:"approvalCode,omitempty"`
 	RRN           *string `json:"rrn,omitempty"`
 	TranID        *string `json:"tranId,omitempty"`
 	Terminal      *string `json:"terminal,omitempty"`
+	Bank          *string `json:"bank,omitempty"`
 	PaymentSystem *string `json:"paymentSystem,omitempty"`
 	PaymentMethod *string `json:"paymentMethod,omitempty"`
 	Fee           *int64  `json:"fee,omitempty"`
+	Country       *string `json:"country,omitempty"`
+	DomesticCard  *bool   `json:"domesticCard,omitempty"`
+}
+
+// IsDomesticCard reports whether card was issued in the merchant country.
+func (p *PaymentInfo) IsDomesticCard() bool {
+	return p != nil && p.DomesticCard != nil && *p.DomesticCard
 }
 
 type WalletData struct {
@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/stremovskyy/recorder"
 )
 
 func TestWalletRequiresToken(t *testing.T) {
@@ -134,3 +136,81 @@ func TestWalletDecodesMaskedPan(t *testing.T) {
 		t.Fatalf("unexpected country: %q", card.Country)
 	}
 }
+
+func TestDeleteWalletCardRequiresCardToken(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+
+	err := client.DeleteWalletCard(NewRequest(), DryRun())
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
+
+func TestDeleteWalletCardDryRunCapturesEndpoint(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(WithToken("merchant-token"))
+
+	var endpoint string
+	err := client.DeleteWalletCard(
+		NewRequest().WithCardToken("tok_1"),
+		DryRun(func(gotEndpoint string, _ any) { endpoint = gotEndpoint }),
+	)
+	if err != nil {
+		t.Fatalf("DeleteWalletCard() unexpected error: %v", err)
+	}
+	if !strings.HasSuffix(endpoint, "/api/merchant/wallet/card?cardToken=tok_1") {
+		t.Fatalf("unexpected dry-run endpoint: %s", endpoint)
+	}
+}
+
+func TestClearWalletDeletesEveryCard(t *testing.T) {
+	t.Parallel()
+
+	storage := &captureStorage{}
+	var deleted []string
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/merchant/wallet":
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write(
+						[]byte(`{"wallet":[{"cardToken":"tok_1","maskedPan":"424242******4242"},{"cardToken":"tok_2","maskedPan":"555555******4444"}]}`),
+					)
+				case r.Method == http.MethodDelete && r.URL.Path == "/api/merchant/wallet/card":
+					if r.Header.Get("X-Token") != "merchant-token" {
+						t.Fatalf("unexpected token header: %q", r.Header.Get("X-Token"))
+					}
+					deleted = append(deleted, r.URL.Query().Get("cardToken"))
+					w.WriteHeader(http.StatusOK)
+				default:
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRecorder(recorder.New(storage)))
+	cards, err := client.ClearWallet(NewRequest().WithToken("merchant-token").WithWalletID("wallet-123"))
+	if err != nil {
+		t.Fatalf("ClearWallet() unexpected error: %v", err)
+	}
+
+	if len(cards) != 2 || len(deleted) != 2 || deleted[0] != "tok_1" || deleted[1] != "tok_2" {
+		t.Fatalf("unexpected deleted cards: returned=%+v server=%v", cards, deleted)
+	}
+
+	var deleteRecords int
+	for _, record := range storage.snapshot() {
+		if record.Type == recorder.RecordTypeRequest && record.Tags["operation"] == "wallet_card_delete" {
+			deleteRecords++
+		}
+	}
+	if deleteRecords != 2 {
+		t.Fatalf("expected 2 recorded delete requests, got %d", deleteRecords)
+	}
+}
@@ -0,0 +1,236 @@
+package go_monobank
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	webhookEventSuccess   = "success"
+	webhookEventFailure   = "failure"
+	webhookEventReversed  = "reversed"
+	webhookEventHold      = "hold"
+	webhookEventTokenized = "tokenized"
+)
+
+// WebhookDispatcher routes verified webhook events to typed handlers by invoice status.
+//
+// Dispatch has WebhookFunc signature, so it can be passed to WebhookHandler or
+// WebhookProcessor directly. Every matching handler is called even if a previous one
+// failed; panics are recovered. Failures are returned as *WebhookDispatchError.
+//
+// Refunds are reported to OnReversed once per RefundStore: events of one invoice are
+// dispatched to OnReversed sequentially, and entries are marked as reported only after
+// every OnReversed handler succeeded. The default store is in-memory and bounded
+// (see NewMemoryRefundStore); use WithRefundStore to share it between replicas.
+type WebhookDispatcher struct {
+	mu          sync.RWMutex
+	onSuccess   []func(ctx context.Context, event *InvoiceStatusResponse) error
+	onFailure   []func(ctx context.Context, event *InvoiceStatusResponse, paymentErr *PaymentError) error
+	onReversed  []func(ctx context.Context, event *InvoiceStatusResponse, refunds []CancelItem) error
+	onHold      []func(ctx context.Context, event *InvoiceStatusResponse) error
+	onTokenized []func(ctx context.Context, event *InvoiceStatusResponse, wallet *WalletData) error
+
+	refunds RefundStore
+	locks   invoiceLocks
+}
+
+// NewWebhookDispatcher creates dispatcher without handlers and with NewMemoryRefundStore(0).
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{refunds: NewMemoryRefundStore(0)}
+}
+
+// WithRefundStore sets store of refunds already reported to OnReversed.
+// Nil store means NewMemoryRefundStore(0).
+func (d *WebhookDispatcher) WithRefundStore(store RefundStore) *WebhookDispatcher {
+	if store == nil {
+		store = NewMemoryRefundStore(0)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.refunds = store
+	return d
+}
+
+// OnSuccess registers handler for status=success.
+func (d *WebhookDispatcher) OnSuccess(fn func(ctx context.Context, event *InvoiceStatusResponse) error) *WebhookDispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onSuccess = append(d.onSuccess, fn)
+	return d
+}
+
+// OnFailure registers handler for status=failure/expired.
+// paymentErr is the error returned by RequireNoPaymentError.
+func (d *WebhookDispatcher) OnFailure(fn func(ctx context.Context, event *InvoiceStatusResponse, paymentErr *PaymentError) error) *WebhookDispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onFailure = append(d.onFailure, fn)
+	return d
+}
+
+// OnReversed registers handler for refunds: status=reversed or new cancelList entries
+// (partial refunds keep status=success). refunds holds only entries not reported
+// before according to the dispatcher's RefundStore.
+func (d *WebhookDispatcher) OnReversed(fn func(ctx context.Context, event *InvoiceStatusResponse, refunds []CancelItem) error) *WebhookDispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onReversed = append(d.onReversed, fn)
+	return d
+}
+
+// OnHoldCreated registers handler for status=hold.
+func (d *WebhookDispatcher) OnHoldCreated(fn func(ctx context.Context, event *InvoiceStatusResponse) error) *WebhookDispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onHold = append(d.onHold, fn)
+	return d
+}
+
+// OnTokenized registers handler for events carrying walletData with cardToken.
+// It is called in addition to the status handler.
+func (d *WebhookDispatcher) OnTokenized(fn func(ctx context.Context, event *InvoiceStatusResponse, wallet *WalletData) error) *WebhookDispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onTokenized = append(d.onTokenized, fn)
+	return d
+}
+
+// Dispatch calls handlers matching event.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, event *InvoiceStatusResponse) error {
+	if event == nil {
+		return &ValidationError{Op: "webhookDispatch", Msg: "event is nil"}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	d.mu.RLock()
+	onSuccess, onFailure, onReversed, onHold, onTokenized := d.onSuccess, d.onFailure, d.onReversed, d.onHold, d.onTokenized
+	store := d.refunds
+	d.mu.RUnlock()
+
+	var errs []*WebhookHandlerError
+	call := func(name string, fn func() error) {
+		if err := callWebhookHandler(name, fn); err != nil {
+			logger.Error("Webhook dispatcher: invoice_id=%s %v", event.InvoiceID, err)
+			errs = append(errs, err)
+		}
+	}
+
+	switch event.Status {
+	case InvoiceSuccess:
+		for _, fn := range onSuccess {
+			call(webhookEventSuccess, func() error { return fn(ctx, event) })
+		}
+	case InvoiceFailure, InvoiceExpired:
+		paymentErr := event.PaymentError()
+		for _, fn := range onFailure {
+			call(webhookEventFailure, func() error { return fn(ctx, event, paymentErr) })
+		}
+	case InvoiceHold:
+		for _, fn := range onHold {
+			call(webhookEventHold, func() error { return fn(ctx, event) })
+		}
+	}
+
+	if len(onReversed) > 0 {
+		errs = append(errs, d.dispatchRefunds(ctx, store, event, onReversed)...)
+	}
+
+	if event.WalletData != nil && event.WalletData.CardToken != "" {
+		for _, fn := range onTokenized {
+			call(webhookEventTokenized, func() error { return fn(ctx, event, event.WalletData) })
+		}
+	}
+
+	if len(errs) > 0 {
+		return &WebhookDispatchError{InvoiceID: event.InvoiceID, Status: event.Status, Errors: errs}
+	}
+	return nil
+}
+
+// dispatchRefunds reports new cancelList entries to onReversed. Check and mark are done
+// under a per-invoice lock, so concurrent deliveries do not report the same refunds twice.
+func (d *WebhookDispatcher) dispatchRefunds(
+	ctx context.Context,
+	store RefundStore,
+	event *InvoiceStatusResponse,
+	onReversed []func(ctx context.Context, event *InvoiceStatusResponse, refunds []CancelItem) error,
+) []*WebhookHandlerError {
+	unlock := d.locks.lock(event.InvoiceID)
+	defer unlock()
+
+	reported, err := store.Reported(ctx, event.InvoiceID)
+	if err != nil {
+		logger.Error("Webhook dispatcher: invoice_id=%s refund store: %v", event.InvoiceID, err)
+		return []*WebhookHandlerError{{Event: webhookEventReversed, Err: err}}
+	}
+	seen := make(map[string]struct{}, len(reported))
+	for _, key := range reported {
+		seen[key] = struct{}{}
+	}
+
+	var (
+		refunds []CancelItem
+		keys    []string
+	)
+	for _, item := range event.CancelList {
+		key := cancelItemKey(item)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		refunds = append(refunds, item)
+		keys = append(keys, key)
+	}
+	// status=reversed is reported even without new cancelList entries, but only once.
+	if event.Status == InvoiceReversed {
+		if _, ok := seen[reversedRefundKey]; !ok {
+			keys = append(keys, reversedRefundKey)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var errs []*WebhookHandlerError
+	for _, fn := range onReversed {
+		if err := callWebhookHandler(webhookEventReversed, func() error { return fn(ctx, event, refunds) }); err != nil {
+			logger.Error("Webhook dispatcher: invoice_id=%s %v", event.InvoiceID, err)
+			errs = append(errs, err)
+		}
+	}
+	// Failed refunds are reported again when monobank redelivers the event.
+	if len(errs) > 0 {
+		return errs
+	}
+	if err := store.MarkReported(ctx, event.InvoiceID, keys); err != nil {
+		logger.Error("Webhook dispatcher: invoice_id=%s refund store: %v", event.InvoiceID, err)
+		return []*WebhookHandlerError{{Event: webhookEventReversed, Err: err}}
+	}
+	return nil
+}
+
+// reversedRefundKey marks in RefundStore that status=reversed itself was reported.
+const reversedRefundKey = "status:reversed"
+
+func cancelItemKey(item CancelItem) string {
+	if item.ExtRef != nil && *item.ExtRef != "" {
+		return "extRef:" + *item.ExtRef
+	}
+	return item.CreatedDate.UTC().Format(time.RFC3339Nano) + "/" + strconv.FormatInt(item.Amount, 10)
+}
+
+func callWebhookHandler(name string, fn func() error) (handlerErr *WebhookHandlerError) {
+	defer func() {
+		if r := recover(); r != nil {
+			handlerErr = &WebhookHandlerError{Event: name, Panic: r}
+		}
+	}()
+	if err := fn(); err != nil {
+		return &WebhookHandlerError{Event: name, Err: err}
+	}
+	return nil
+}
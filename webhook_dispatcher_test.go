@@ -0,0 +1,213 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookDispatcherRoutesByStatus(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	d := NewWebhookDispatcher().
+		OnSuccess(func(_ context.Context, event *InvoiceStatusResponse) error {
+			calls = append(calls, "success")
+			return nil
+		}).
+		OnFailure(func(_ context.Context, event *InvoiceStatusResponse, paymentErr *PaymentError) error {
+			if paymentErr == nil || paymentErr.ErrCode != "59" {
+				t.Fatalf("unexpected payment error: %+v", paymentErr)
+			}
+			calls = append(calls, "failure")
+			return nil
+		}).
+		OnHoldCreated(func(_ context.Context, event *InvoiceStatusResponse) error {
+			calls = append(calls, "hold")
+			return nil
+		}).
+		OnTokenized(func(_ context.Context, event *InvoiceStatusResponse, wallet *WalletData) error {
+			calls = append(calls, "tokenized:"+wallet.CardToken)
+			return nil
+		})
+
+	errCode := "59"
+	events := []*InvoiceStatusResponse{
+		{InvoiceID: "inv-1", Status: InvoiceSuccess, WalletData: &WalletData{CardToken: "card-1", WalletID: "wallet-1"}},
+		{InvoiceID: "inv-2", Status: InvoiceFailure, ErrCode: &errCode},
+		{InvoiceID: "inv-3", Status: InvoiceHold},
+		{InvoiceID: "inv-4", Status: InvoiceProcessing},
+	}
+	for _, event := range events {
+		if err := d.Dispatch(context.Background(), event); err != nil {
+			t.Fatalf("Dispatch(%s) unexpected error: %v", event.Status, err)
+		}
+	}
+
+	want := []string{"success", "tokenized:card-1", "failure", "hold"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("calls = %v, want %v", calls, want)
+		}
+	}
+}
+
+func TestWebhookDispatcherReportsOnlyNewRefunds(t *testing.T) {
+	t.Parallel()
+
+	var got [][]CancelItem
+	d := NewWebhookDispatcher().OnReversed(func(_ context.Context, _ *InvoiceStatusResponse, refunds []CancelItem) error {
+		got = append(got, refunds)
+		return nil
+	})
+
+	base := time.Date(2026, 2, 26, 10, 0, 0, 0, time.UTC)
+	first := CancelItem{Status: InvoiceSuccess, Amount: 100, CreatedDate: base}
+	second := CancelItem{Status: InvoiceSuccess, Amount: 200, CreatedDate: base.Add(time.Minute)}
+
+	partial := &InvoiceStatusResponse{InvoiceID: "inv-1", Status: InvoiceSuccess, CancelList: []CancelItem{first}}
+	full := &InvoiceStatusResponse{InvoiceID: "inv-1", Status: InvoiceReversed, CancelList: []CancelItem{first, second}}
+
+	for _, event := range []*InvoiceStatusResponse{partial, partial, full} {
+		if err := d.Dispatch(context.Background(), event); err != nil {
+			t.Fatalf("Dispatch() unexpected error: %v", err)
+		}
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("OnReversed calls = %d, want 2", len(got))
+	}
+	if len(got[0]) != 1 || got[0][0].Amount != 100 {
+		t.Fatalf("first refunds = %+v", got[0])
+	}
+	if len(got[1]) != 1 || got[1][0].Amount != 200 {
+		t.Fatalf("second refunds must contain only new entry, got %+v", got[1])
+	}
+}
+
+func TestWebhookDispatcherReportsRedeliveredReversalOnce(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	d := NewWebhookDispatcher().OnReversed(func(_ context.Context, _ *InvoiceStatusResponse, _ []CancelItem) error {
+		calls++
+		return nil
+	})
+
+	refund := CancelItem{Status: InvoiceSuccess, Amount: 100, CreatedDate: time.Date(2026, 2, 26, 10, 0, 0, 0, time.UTC)}
+	withRefund := &InvoiceStatusResponse{InvoiceID: "inv-1", Status: InvoiceReversed, CancelList: []CancelItem{refund}}
+	withoutRefund := &InvoiceStatusResponse{InvoiceID: "inv-2", Status: InvoiceReversed}
+
+	// monobank retries webhooks: every event arrives twice.
+	for _, event := range []*InvoiceStatusResponse{withRefund, withRefund, withoutRefund, withoutRefund} {
+		if err := d.Dispatch(context.Background(), event); err != nil {
+			t.Fatalf("Dispatch() unexpected error: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("OnReversed calls = %d, want 2 (one per invoice)", calls)
+	}
+}
+
+func TestWebhookDispatcherReportsRefundsOnceConcurrently(t *testing.T) {
+	t.Parallel()
+
+	var reported atomic.Int32
+	d := NewWebhookDispatcher().OnReversed(func(_ context.Context, _ *InvoiceStatusResponse, refunds []CancelItem) error {
+		time.Sleep(10 * time.Millisecond)
+		reported.Add(int32(len(refunds)))
+		return nil
+	})
+
+	event := &InvoiceStatusResponse{
+		InvoiceID:  "inv-1",
+		Status:     InvoiceSuccess,
+		CancelList: []CancelItem{{Status: InvoiceSuccess, Amount: 100, CreatedDate: time.Date(2026, 2, 26, 10, 0, 0, 0, time.UTC)}},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.Dispatch(context.Background(), event); err != nil {
+				t.Errorf("Dispatch() unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := reported.Load(); got != 1 {
+		t.Fatalf("refund reported %d times, want 1", got)
+	}
+}
+
+func TestWebhookDispatcherSharesRefundStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryRefundStore(1)
+	calls := 0
+	onReversed := func(_ context.Context, _ *InvoiceStatusResponse, _ []CancelItem) error {
+		calls++
+		return nil
+	}
+	refund := CancelItem{Status: InvoiceSuccess, Amount: 100, CreatedDate: time.Date(2026, 2, 26, 10, 0, 0, 0, time.UTC)}
+	event := func(invoiceID string) *InvoiceStatusResponse {
+		return &InvoiceStatusResponse{InvoiceID: invoiceID, Status: InvoiceSuccess, CancelList: []CancelItem{refund}}
+	}
+
+	// A second replica (or a restarted process) with the same store must not report refunds again.
+	first := NewWebhookDispatcher().WithRefundStore(store).OnReversed(onReversed)
+	second := NewWebhookDispatcher().WithRefundStore(store).OnReversed(onReversed)
+	for _, d := range []*WebhookDispatcher{first, second} {
+		if err := d.Dispatch(context.Background(), event("inv-1")); err != nil {
+			t.Fatalf("Dispatch() unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("OnReversed calls = %d, want 1", calls)
+	}
+
+	// The store is bounded: inv-2 evicts inv-1.
+	if err := first.Dispatch(context.Background(), event("inv-2")); err != nil {
+		t.Fatalf("Dispatch() unexpected error: %v", err)
+	}
+	if keys, _ := store.Reported(context.Background(), "inv-1"); len(keys) != 0 {
+		t.Fatalf("inv-1 must be evicted, got %v", keys)
+	}
+}
+
+func TestWebhookDispatcherAggregatesErrorsAndRecoversPanics(t *testing.T) {
+	t.Parallel()
+
+	errDB := errors.New("db is down")
+	secondCalled := false
+	d := NewWebhookDispatcher().
+		OnSuccess(func(context.Context, *InvoiceStatusResponse) error { panic("boom") }).
+		OnSuccess(func(context.Context, *InvoiceStatusResponse) error {
+			secondCalled = true
+			return errDB
+		})
+
+	err := d.Dispatch(context.Background(), &InvoiceStatusResponse{InvoiceID: "inv-1", Status: InvoiceSuccess})
+	if !secondCalled {
+		t.Fatalf("handlers after a panicking one must still be called")
+	}
+	if !errors.Is(err, ErrWebhookHandler) || !errors.Is(err, errDB) {
+		t.Fatalf("expected aggregated handler errors, got %v", err)
+	}
+
+	var dispatchErr *WebhookDispatchError
+	if !errors.As(err, &dispatchErr) || len(dispatchErr.Errors) != 2 {
+		t.Fatalf("expected 2 handler errors, got %v", err)
+	}
+	if dispatchErr.Errors[0].Panic != "boom" {
+		t.Fatalf("first error must carry recovered panic, got %+v", dispatchErr.Errors[0])
+	}
+}
@@ -0,0 +1,128 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const defaultWebhookMaxBodySize int64 = 1 << 20
+
+// WebhookFunc handles a verified webhook event.
+// Returning an error makes the handler respond 500 so monobank redelivers the webhook.
+type WebhookFunc func(ctx context.Context, event *InvoiceStatusResponse) error
+
+// WebhookHandlerOption configures WebhookHandler.
+type WebhookHandlerOption func(*webhookHandler)
+
+// WithWebhookMaxBodySize limits accepted webhook body size (default 1 MiB).
+func WithWebhookMaxBodySize(n int64) WebhookHandlerOption {
+	return func(h *webhookHandler) {
+		if n > 0 {
+			h.maxBodySize = n
+		}
+	}
+}
+
+type webhookHandler struct {
+	client      Monobank
+	fn          WebhookFunc
+	maxBodySize int64
+}
+
+// WebhookHandler returns http.Handler that verifies X-Sign, parses the event and passes it
+// to fn together with request context.
+//
+// Responses:
+//   - 405 for non-POST requests
+//   - 413 when body exceeds max size
+//   - 400 for empty body or malformed payload/signature (DecodeError)
+//   - 401 for missing or invalid signature (WebhookSignatureError)
+//   - 503 when public key cannot be resolved, 500 when fn fails (monobank redelivers)
+//   - 200 when fn succeeds
+func WebhookHandler(client Monobank, fn WebhookFunc, opts ...WebhookHandlerOption) http.Handler {
+	h := &webhookHandler{
+		client:      client,
+		fn:          fn,
+		maxBodySize: defaultWebhookMaxBodySize,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(h)
+		}
+	}
+	return h
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			logger.Warn("Webhook handler: body exceeds %d bytes", h.maxBodySize)
+			http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		logger.Error("Webhook handler: read body error: %v", err)
+		http.Error(w, "bad body", http.StatusBadRequest)
+		return
+	}
+	if len(body) == 0 {
+		http.Error(w, "empty body", http.StatusBadRequest)
+		return
+	}
+
+	xSign := strings.TrimSpace(r.Header.Get("X-Sign"))
+	if xSign == "" {
+		http.Error(w, "missing X-Sign", http.StatusUnauthorized)
+		return
+	}
+
+	if h.client == nil {
+		logger.Error("Webhook handler: client is nil")
+		http.Error(w, "webhook handler is not configured", http.StatusInternalServerError)
+		return
+	}
+
+	event, err := h.client.ParseAndVerifyWebhookContext(r.Context(), body, xSign)
+	if err != nil {
+		status := webhookErrorStatus(err)
+		logger.Warn("Webhook handler: rejected with status=%d err=%v", status, err)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if h.fn != nil {
+		if err := h.fn(r.Context(), event); err != nil {
+			logger.Error("Webhook handler: callback failed invoice_id=%s err=%v", event.InvoiceID, err)
+			http.Error(w, "webhook processing failed", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrInvalidSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrDecode):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrValidation):
+		// Public key is not configured: our side is misconfigured, let monobank redeliver.
+		return http.StatusInternalServerError
+	default:
+		// Public key could not be fetched (transport/API error).
+		return http.StatusServiceUnavailable
+	}
+}
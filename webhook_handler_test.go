@@ -0,0 +1,118 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testWebhookBody = `{"invoiceId":"inv-1","status":"success","amount":100,"ccy":980}`
+
+func newTestWebhookKey(t *testing.T) (*WebhookSigner, string) {
+	t.Helper()
+
+	signer, err := NewWebhookSigner()
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+	return signer, signer.PublicKeyBase64()
+}
+
+func signTestWebhook(t *testing.T, signer *WebhookSigner, body string) string {
+	t.Helper()
+
+	xSign, err := signer.Sign([]byte(body))
+	if err != nil {
+		t.Fatalf("sign body: %v", err)
+	}
+	return xSign
+}
+
+func TestWebhookHandlerResponses(t *testing.T) {
+	t.Parallel()
+
+	key, pubKey := newTestWebhookKey(t)
+	client := NewClient(WithWebhookPublicKeyBase64(pubKey))
+
+	type ctxKey struct{}
+	var got *InvoiceStatusResponse
+	handler := WebhookHandler(
+		client,
+		func(ctx context.Context, event *InvoiceStatusResponse) error {
+			if ctx.Value(ctxKey{}) != "request-ctx" {
+				t.Fatalf("callback must receive request context")
+			}
+			got = event
+			if event.InvoiceID == "fail" {
+				return errors.New("db is down")
+			}
+			return nil
+		},
+		WithWebhookMaxBodySize(256),
+	)
+
+	failBody := `{"invoiceId":"fail","status":"success"}`
+	cases := []struct {
+		name   string
+		method string
+		body   string
+		xSign  string
+		want   int
+	}{
+		{name: "ok", method: http.MethodPost, body: testWebhookBody, xSign: signTestWebhook(t, key, testWebhookBody), want: http.StatusOK},
+		{name: "method", method: http.MethodGet, want: http.StatusMethodNotAllowed},
+		{name: "too large", method: http.MethodPost, body: strings.Repeat("x", 512), xSign: "c2ln", want: http.StatusRequestEntityTooLarge},
+		{name: "no sign", method: http.MethodPost, body: testWebhookBody, want: http.StatusUnauthorized},
+		{name: "bad sign", method: http.MethodPost, body: testWebhookBody, xSign: signTestWebhook(t, key, "other"), want: http.StatusUnauthorized},
+		{name: "bad base64", method: http.MethodPost, body: testWebhookBody, xSign: "%%%", want: http.StatusBadRequest},
+		{name: "bad json", method: http.MethodPost, body: "{", xSign: signTestWebhook(t, key, "{"), want: http.StatusBadRequest},
+		{name: "callback fails", method: http.MethodPost, body: failBody, xSign: signTestWebhook(t, key, failBody), want: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "/webhook", strings.NewReader(tc.body))
+		req = req.WithContext(context.WithValue(req.Context(), ctxKey{}, "request-ctx"))
+		if tc.xSign != "" {
+			req.Header.Set("X-Sign", tc.xSign)
+		}
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tc.want {
+			t.Fatalf("%s: status = %d, want %d (body=%s)", tc.name, rec.Code, tc.want, rec.Body.String())
+		}
+	}
+
+	if got == nil || got.InvoiceID != "fail" {
+		t.Fatalf("callback must receive verified events, last = %+v", got)
+	}
+}
+
+func TestWebhookHandlerUnavailablePublicKey(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+		),
+	)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+	handler := WebhookHandler(client, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(testWebhookBody))
+	req.Header.Set("X-Sign", "c2ln")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,233 @@
+package go_monobank
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stremovskyy/go-monobank/consts"
+)
+
+const (
+	defaultWebhookKeyRefetchInterval = time.Minute
+	defaultWebhookKeyGracePeriod     = time.Hour
+)
+
+// InvalidateWebhookPublicKey drops cached webhook public key(s).
+// Next verification fetches the key from /api/merchant/pubkey (when default token is set).
+func (c *client) InvalidateWebhookPublicKey() {
+	c.pubKeyMu.Lock()
+	defer c.pubKeyMu.Unlock()
+
+	c.pubKey = nil
+	c.prevPubKey = nil
+	c.prevPubKeyUntil = time.Time{}
+	c.pubKeyFetchedAt = time.Time{}
+	c.pubKeyFetchErr = nil
+	c.pubKeyStale = true
+	logger.Info("Webhook pubkey: cache invalidated")
+}
+
+// webhookPublicKeys returns current key first, then previous key while its grace window lasts.
+func (c *client) webhookPublicKeys(ctx context.Context) ([]*ecdsa.PublicKey, error) {
+	if keys := c.cachedWebhookPublicKeys(); len(keys) > 0 {
+		return keys, nil
+	}
+	if err := c.loadWebhookPublicKey(ctx); err != nil {
+		return nil, err
+	}
+	if keys := c.cachedWebhookPublicKeys(); len(keys) > 0 {
+		return keys, nil
+	}
+	return nil, fmt.Errorf("pubkey: key is not available")
+}
+
+func (c *client) cachedWebhookPublicKeys() []*ecdsa.PublicKey {
+	c.pubKeyMu.Lock()
+	defer c.pubKeyMu.Unlock()
+
+	if c.pubKey == nil {
+		return nil
+	}
+	keys := []*ecdsa.PublicKey{c.pubKey}
+	if c.prevPubKey != nil && time.Now().Before(c.prevPubKeyUntil) {
+		keys = append(keys, c.prevPubKey)
+	}
+	return keys
+}
+
+// loadWebhookPublicKey resolves initial key: configured PEM, configured base64 PEM, then API.
+// After InvalidateWebhookPublicKey, API is preferred over configured keys.
+// A failed fetch is not repeated within the refetch interval; its error is returned instead.
+func (c *client) loadWebhookPublicKey(ctx context.Context) error {
+	c.pubKeyFetchMu.Lock()
+	defer c.pubKeyFetchMu.Unlock()
+
+	c.pubKeyMu.Lock()
+	loaded := c.pubKey != nil
+	stale := c.pubKeyStale
+	fetchedAt := c.pubKeyFetchedAt
+	fetchErr := c.pubKeyFetchErr
+	c.pubKeyMu.Unlock()
+	if loaded {
+		return nil
+	}
+
+	canFetch := strings.TrimSpace(c.cfg.defaultToken) != ""
+	if canFetch && fetchErr != nil && time.Since(fetchedAt) < c.cfg.webhookKeyRefetchInterval {
+		logger.Debug("Webhook pubkey: fetch skipped, last fetch at %s failed", fetchedAt.Format(time.RFC3339))
+		return fetchErr
+	}
+	if stale && canFetch {
+		return c.fetchWebhookPublicKey(ctx)
+	}
+
+	// 1) Raw PEM provided
+	if len(c.cfg.webhookPublicKeyPEM) > 0 {
+		pub, err := parseECDSAPublicKeyFromPEM(c.cfg.webhookPublicKeyPEM)
+		if err != nil {
+			return fmt.Errorf("pubkey: parse PEM: %w", err)
+		}
+		c.setWebhookPublicKey(pub, base64.StdEncoding.EncodeToString(c.cfg.webhookPublicKeyPEM))
+		return nil
+	}
+
+	// 2) Base64 PEM provided
+	if key := strings.TrimSpace(c.cfg.webhookPublicKeyBase64); key != "" {
+		pub, err := parseWebhookPublicKeyBase64(key)
+		if err != nil {
+			return err
+		}
+		c.setWebhookPublicKey(pub, key)
+		return nil
+	}
+
+	// 3) Fetch from API using default token
+	if !canFetch {
+		return &ValidationError{Op: "pubkey", Msg: "public key not configured and default token is empty; set WithWebhookPublicKeyBase64(...) or WithToken(...)"}
+	}
+	return c.fetchWebhookPublicKey(ctx)
+}
+
+// refetchWebhookPublicKey fetches key from API after a signature failure.
+// seen is the key the failed verification used; if another goroutine has already
+// replaced it, no request is made. Refetches are limited to one per refetch interval.
+func (c *client) refetchWebhookPublicKey(ctx context.Context, seen *ecdsa.PublicKey) (bool, error) {
+	if strings.TrimSpace(c.cfg.defaultToken) == "" {
+		return false, nil
+	}
+
+	c.pubKeyFetchMu.Lock()
+	defer c.pubKeyFetchMu.Unlock()
+
+	c.pubKeyMu.Lock()
+	current := c.pubKey
+	fetchedAt := c.pubKeyFetchedAt
+	c.pubKeyMu.Unlock()
+
+	if current != seen {
+		return true, nil
+	}
+	if !fetchedAt.IsZero() && time.Since(fetchedAt) < c.cfg.webhookKeyRefetchInterval {
+		logger.Debug("Webhook pubkey: refetch skipped, last fetch at %s", fetchedAt.Format(time.RFC3339))
+		return false, nil
+	}
+
+	if err := c.fetchWebhookPublicKey(ctx); err != nil {
+		return false, err
+	}
+
+	c.pubKeyMu.Lock()
+	defer c.pubKeyMu.Unlock()
+	return c.pubKey != nil && !c.pubKey.Equal(seen), nil
+}
+
+// fetchWebhookPublicKey must be called with pubKeyFetchMu held.
+// A fetch aborted by caller context is not remembered: it says nothing about the API.
+func (c *client) fetchWebhookPublicKey(ctx context.Context) error {
+	c.pubKeyMu.Lock()
+	prevFetchedAt := c.pubKeyFetchedAt
+	c.pubKeyFetchedAt = time.Now()
+	c.pubKeyMu.Unlock()
+
+	err := c.doFetchWebhookPublicKey(ctx)
+
+	c.pubKeyMu.Lock()
+	defer c.pubKeyMu.Unlock()
+	if err != nil && (ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		c.pubKeyFetchedAt = prevFetchedAt
+		return err
+	}
+	c.pubKeyFetchErr = err
+	return err
+}
+
+func (c *client) doFetchWebhookPublicKey(ctx context.Context) error {
+	var resp PublicKeyResponse
+	if err := c.doJSON(ctx, http.MethodGet, consts.PathPubKey, c.cfg.defaultToken, nil, nil, &resp); err != nil {
+		return err
+	}
+	key := strings.TrimSpace(resp.Key)
+	if key == "" {
+		return fmt.Errorf("pubkey: empty key in response")
+	}
+	pub, err := parseWebhookPublicKeyBase64(key)
+	if err != nil {
+		return err
+	}
+	c.setWebhookPublicKey(pub, key)
+	return nil
+}
+
+// setWebhookPublicKey makes pub current; replaced key stays valid for the grace period.
+func (c *client) setWebhookPublicKey(pub *ecdsa.PublicKey, keyBase64 string) {
+	c.pubKeyMu.Lock()
+	if c.pubKey != nil && !c.pubKey.Equal(pub) {
+		c.prevPubKey = c.pubKey
+		c.prevPubKeyUntil = time.Now().Add(c.cfg.webhookKeyGracePeriod)
+	}
+	previous := c.pubKeyBase64
+	changed := false
+	if previous != "" && previous != keyBase64 {
+		// Same key may be encoded differently (configured PEM vs API response).
+		prevPub, err := parseWebhookPublicKeyBase64(previous)
+		changed = err != nil || !prevPub.Equal(pub)
+	}
+	c.pubKey = pub
+	c.pubKeyBase64 = keyBase64
+	c.pubKeyStale = false
+	c.pubKeyMu.Unlock()
+
+	if changed {
+		logger.Warn("Webhook pubkey: key has changed")
+		if c.cfg.onWebhookKeyChange != nil {
+			c.cfg.onWebhookKeyChange(previous, keyBase64)
+		}
+	}
+}
+
+func parseWebhookPublicKeyBase64(key string) (*ecdsa.PublicKey, error) {
+	pemBytes, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("pubkey: base64 decode: %w", err)
+	}
+	pub, err := parseECDSAPublicKeyFromPEM(pemBytes)
+	if err != nil {
+		return nil, fmt.Errorf("pubkey: parse decoded PEM: %w", err)
+	}
+	return pub, nil
+}
+
+func verifyWithAnyKey(keys []*ecdsa.PublicKey, hash, sig []byte) bool {
+	for _, key := range keys {
+		if key != nil && ecdsa.VerifyASN1(key, hash, sig) {
+			return true
+		}
+	}
+	return false
+}
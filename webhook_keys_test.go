@@ -0,0 +1,206 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type pubKeyServer struct {
+	*httptest.Server
+	key   atomic.Value
+	calls atomic.Int32
+}
+
+func newPubKeyServer(t *testing.T, key string) *pubKeyServer {
+	t.Helper()
+
+	s := &pubKeyServer{}
+	s.key.Store(key)
+	s.Server = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				s.calls.Add(1)
+				time.Sleep(10 * time.Millisecond)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"key":"` + s.key.Load().(string) + `"}`))
+			},
+		),
+	)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestVerifyWebhookRefetchesRotatedKey(t *testing.T) {
+	t.Parallel()
+
+	oldKey, oldPub := newTestWebhookKey(t)
+	newKey, newPub := newTestWebhookKey(t)
+	server := newPubKeyServer(t, oldPub)
+
+	var changes atomic.Int32
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithToken("merchant-token"),
+		WithWebhookKeyRefetchInterval(0),
+		WithWebhookKeyChangeHandler(
+			func(previousKey, currentKey string) {
+				if previousKey != oldPub || currentKey != newPub {
+					t.Errorf("unexpected key change %q -> %q", previousKey, currentKey)
+				}
+				changes.Add(1)
+			},
+		),
+	)
+
+	if err := client.VerifyWebhook([]byte(testWebhookBody), signTestWebhook(t, oldKey, testWebhookBody)); err != nil {
+		t.Fatalf("verify with initial key: %v", err)
+	}
+
+	server.key.Store(newPub)
+
+	// Concurrent failures share a single refetch.
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.VerifyWebhook([]byte(testWebhookBody), signTestWebhook(t, newKey, testWebhookBody))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("verify with rotated key: %v", err)
+		}
+	}
+
+	if got := server.calls.Load(); got != 2 {
+		t.Fatalf("pubkey calls = %d, want 2", got)
+	}
+	if got := changes.Load(); got != 1 {
+		t.Fatalf("key change callbacks = %d, want 1", got)
+	}
+
+	// Previous key is still valid during grace period.
+	if err := client.VerifyWebhook([]byte(testWebhookBody), signTestWebhook(t, oldKey, testWebhookBody)); err != nil {
+		t.Fatalf("verify with previous key in grace period: %v", err)
+	}
+}
+
+func TestVerifyWebhookRefetchIsRateLimited(t *testing.T) {
+	t.Parallel()
+
+	key, pub := newTestWebhookKey(t)
+	otherKey, _ := newTestWebhookKey(t)
+	server := newPubKeyServer(t, pub)
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	if err := client.VerifyWebhook([]byte(testWebhookBody), signTestWebhook(t, key, testWebhookBody)); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		err := client.VerifyWebhook([]byte(testWebhookBody), signTestWebhook(t, otherKey, testWebhookBody))
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("expected ErrInvalidSignature, got %v", err)
+		}
+	}
+	if got := server.calls.Load(); got != 1 {
+		t.Fatalf("pubkey calls = %d, want 1 (refetch within interval must be skipped)", got)
+	}
+
+	client.InvalidateWebhookPublicKey()
+	if err := client.VerifyWebhookContext(context.Background(), []byte(testWebhookBody), signTestWebhook(t, key, testWebhookBody)); err != nil {
+		t.Fatalf("verify after invalidate: %v", err)
+	}
+	if got := server.calls.Load(); got != 2 {
+		t.Fatalf("pubkey calls after invalidate = %d, want 2", got)
+	}
+}
+
+func TestInvalidateWebhookPublicKeyPrefersAPIOverConfiguredKey(t *testing.T) {
+	t.Parallel()
+
+	_, configuredPub := newTestWebhookKey(t)
+	apiKey, apiPub := newTestWebhookKey(t)
+	server := newPubKeyServer(t, apiPub)
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithToken("merchant-token"),
+		WithWebhookPublicKeyBase64(configuredPub),
+	)
+	client.InvalidateWebhookPublicKey()
+
+	if err := client.VerifyWebhook([]byte(testWebhookBody), signTestWebhook(t, apiKey, testWebhookBody)); err != nil {
+		t.Fatalf("verify with API key after invalidate: %v", err)
+	}
+	if got := server.calls.Load(); got != 1 {
+		t.Fatalf("pubkey calls = %d, want 1", got)
+	}
+}
+
+func TestVerifyWebhookFailedFetchIsRateLimited(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+		),
+	)
+	defer server.Close()
+
+	key, _ := newTestWebhookKey(t)
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+
+	for i := 0; i < 3; i++ {
+		err := client.VerifyWebhook([]byte(testWebhookBody), signTestWebhook(t, key, testWebhookBody))
+		if !errors.Is(err, ErrServerError) {
+			t.Fatalf("attempt %d: expected last fetch error, got %v", i, err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("pubkey calls = %d, want 1 (failed fetch must not be repeated within interval)", got)
+	}
+
+	client.InvalidateWebhookPublicKey()
+	_ = client.VerifyWebhook([]byte(testWebhookBody), signTestWebhook(t, key, testWebhookBody))
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("pubkey calls after invalidate = %d, want 2", got)
+	}
+}
+
+func TestVerifyWebhookCancelledFetchIsNotRemembered(t *testing.T) {
+	t.Parallel()
+
+	key, pubKey := newTestWebhookKey(t)
+	server := newPubKeyServer(t, pubKey)
+	client := NewClient(WithBaseURL(server.URL), WithToken("merchant-token"))
+	xSign := signTestWebhook(t, key, testWebhookBody)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.ParseAndVerifyWebhookContext(ctx, []byte(testWebhookBody), xSign); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	before := server.calls.Load()
+
+	if _, err := client.ParseAndVerifyWebhookContext(context.Background(), []byte(testWebhookBody), xSign); err != nil {
+		t.Fatalf("verification after cancelled fetch: %v", err)
+	}
+	if got := server.calls.Load(); got != before+1 {
+		t.Fatalf("pubkey calls = %d, want %d (cancelled fetch must not block the next one)", got, before+1)
+	}
+}
@@ -0,0 +1,208 @@
+package go_monobank
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WebhookDropReason explains why WebhookProcessor skipped an event.
+type WebhookDropReason string
+
+const (
+	// WebhookDropDuplicate means the same status with the same modifiedDate was already processed.
+	WebhookDropDuplicate WebhookDropReason = "duplicate"
+	// WebhookDropStale means a newer event (by modifiedDate) was already processed.
+	WebhookDropStale WebhookDropReason = "stale"
+	// WebhookDropRegression means an event with the same modifiedDate would move
+	// invoice back to an earlier status (e.g. processing after success).
+	WebhookDropRegression WebhookDropReason = "status_regression"
+	// WebhookDropInvalid means the event has no invoiceId.
+	WebhookDropInvalid WebhookDropReason = "invalid"
+)
+
+// WebhookState is the last processed webhook state of an invoice.
+type WebhookState struct {
+	InvoiceID    string        `json:"invoiceId"`
+	Status       InvoiceStatus `json:"status"`
+	ModifiedDate time.Time     `json:"modifiedDate"`
+}
+
+// WebhookStore persists last processed state per invoiceId for WebhookProcessor.
+type WebhookStore interface {
+	// Get returns stored state; nil without error when invoice is unknown.
+	Get(ctx context.Context, invoiceID string) (*WebhookState, error)
+	// Put stores state of a processed event.
+	Put(ctx context.Context, state WebhookState) error
+}
+
+// WebhookResult describes what WebhookProcessor did with an event.
+type WebhookResult struct {
+	Event *InvoiceStatusResponse
+	// Processed is true when the callback was called and succeeded.
+	Processed bool
+	// Reason is set when the event was dropped.
+	Reason WebhookDropReason
+	// Previous is the stored state the event was compared with (nil for the first event).
+	Previous *WebhookState
+}
+
+// WebhookProcessor drops duplicate and out-of-order webhooks before they reach business logic.
+//
+// Events of one invoice are processed sequentially within a processor. State is stored only
+// after the callback succeeds, so a failed event is processed again when monobank redelivers it.
+type WebhookProcessor struct {
+	client Monobank
+	store  WebhookStore
+
+	locks invoiceLocks
+}
+
+// invoiceLocks serializes work per invoiceId; idle locks are released.
+type invoiceLocks struct {
+	mu    sync.Mutex
+	locks map[string]*invoiceLock
+}
+
+type invoiceLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewWebhookProcessor creates processor that uses client to parse webhooks and store
+// to remember processed events. Nil store means NewMemoryWebhookStore().
+func NewWebhookProcessor(client Monobank, store WebhookStore) *WebhookProcessor {
+	if store == nil {
+		store = NewMemoryWebhookStore()
+	}
+	return &WebhookProcessor{
+		client: client,
+		store:  store,
+	}
+}
+
+// Handle parses body (see ParseWebhook) and processes the event.
+// Body must already be verified (VerifyWebhook or WebhookHandler).
+func (p *WebhookProcessor) Handle(ctx context.Context, body []byte, fn WebhookFunc) (*WebhookResult, error) {
+	if p.client == nil {
+		return nil, &ValidationError{Op: "webhookProcessor", Msg: "client is nil"}
+	}
+	event, err := p.client.ParseWebhook(body)
+	if err != nil {
+		return nil, err
+	}
+	return p.Process(ctx, event, fn)
+}
+
+// Process calls fn for event unless it is a duplicate or older than already processed one.
+// Dropped events are reported via WebhookResult.Reason with nil error.
+func (p *WebhookProcessor) Process(ctx context.Context, event *InvoiceStatusResponse, fn WebhookFunc) (*WebhookResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if event == nil {
+		return nil, &ValidationError{Op: "webhookProcessor", Msg: "event is nil"}
+	}
+	result := &WebhookResult{Event: event}
+	if event.InvoiceID == "" {
+		result.Reason = WebhookDropInvalid
+		logger.Warn("Webhook processor: dropped event without invoiceId")
+		return result, nil
+	}
+
+	unlock := p.locks.lock(event.InvoiceID)
+	defer unlock()
+
+	previous, err := p.store.Get(ctx, event.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	result.Previous = previous
+
+	if reason := webhookDropReason(previous, event); reason != "" {
+		result.Reason = reason
+		logger.Info(
+			"Webhook processor: dropped invoice_id=%s status=%s modified=%s reason=%s",
+			event.InvoiceID,
+			event.Status,
+			event.ModifiedDate.Format(time.RFC3339Nano),
+			reason,
+		)
+		return result, nil
+	}
+
+	if fn != nil {
+		if err := fn(ctx, event); err != nil {
+			return result, err
+		}
+	}
+
+	state := WebhookState{InvoiceID: event.InvoiceID, Status: event.Status, ModifiedDate: event.ModifiedDate}
+	if err := p.store.Put(ctx, state); err != nil {
+		return result, err
+	}
+	result.Processed = true
+	return result, nil
+}
+
+func (l *invoiceLocks) lock(invoiceID string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*invoiceLock)
+	}
+	il, ok := l.locks[invoiceID]
+	if !ok {
+		il = &invoiceLock{}
+		l.locks[invoiceID] = il
+	}
+	il.refs++
+	l.mu.Unlock()
+
+	il.mu.Lock()
+	return func() {
+		il.mu.Unlock()
+		l.mu.Lock()
+		il.refs--
+		if il.refs == 0 {
+			delete(l.locks, invoiceID)
+		}
+		l.mu.Unlock()
+	}
+}
+
+func webhookDropReason(previous *WebhookState, event *InvoiceStatusResponse) WebhookDropReason {
+	if previous == nil {
+		return ""
+	}
+	switch {
+	case event.ModifiedDate.Before(previous.ModifiedDate):
+		return WebhookDropStale
+	case event.ModifiedDate.After(previous.ModifiedDate):
+		return ""
+	case event.Status == previous.Status:
+		return WebhookDropDuplicate
+	case invoiceStatusRank(event.Status) <= invoiceStatusRank(previous.Status):
+		return WebhookDropRegression
+	default:
+		return ""
+	}
+}
+
+// invoiceStatusRank orders statuses by lifecycle for events with equal modifiedDate.
+func invoiceStatusRank(s InvoiceStatus) int {
+	switch s {
+	case InvoiceCreated:
+		return 0
+	case InvoiceProcessing:
+		return 1
+	case InvoiceHold:
+		return 2
+	case InvoiceReversed:
+		return 4
+	default:
+		if s.IsFinal() {
+			return 3
+		}
+		return 1
+	}
+}
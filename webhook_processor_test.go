@@ -0,0 +1,119 @@
+package go_monobank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func webhookEvent(status InvoiceStatus, modified time.Time) *InvoiceStatusResponse {
+	return &InvoiceStatusResponse{InvoiceID: "inv-1", Status: status, ModifiedDate: modified}
+}
+
+func TestWebhookProcessorDropsDuplicatesAndStaleEvents(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 2, 26, 10, 0, 0, 0, time.UTC)
+	processor := NewWebhookProcessor(NewClient(), nil)
+
+	var seen []InvoiceStatus
+	fn := func(_ context.Context, event *InvoiceStatusResponse) error {
+		seen = append(seen, event.Status)
+		return nil
+	}
+
+	cases := []struct {
+		event  *InvoiceStatusResponse
+		reason WebhookDropReason
+	}{
+		{event: webhookEvent(InvoiceProcessing, base)},
+		{event: webhookEvent(InvoiceSuccess, base.Add(time.Second))},
+		{event: webhookEvent(InvoiceSuccess, base.Add(time.Second)), reason: WebhookDropDuplicate},
+		{event: webhookEvent(InvoiceProcessing, base), reason: WebhookDropStale},
+		{event: webhookEvent(InvoiceProcessing, base.Add(time.Second)), reason: WebhookDropRegression},
+		{event: webhookEvent(InvoiceReversed, base.Add(time.Second))},
+		{event: &InvoiceStatusResponse{Status: InvoiceSuccess}, reason: WebhookDropInvalid},
+	}
+
+	for i, tc := range cases {
+		result, err := processor.Process(context.Background(), tc.event, fn)
+		if err != nil {
+			t.Fatalf("case %d: unexpected error: %v", i, err)
+		}
+		if result.Reason != tc.reason {
+			t.Fatalf("case %d: reason = %q, want %q", i, result.Reason, tc.reason)
+		}
+		if result.Processed != (tc.reason == "") {
+			t.Fatalf("case %d: processed = %v", i, result.Processed)
+		}
+	}
+
+	want := fmt.Sprint([]InvoiceStatus{InvoiceProcessing, InvoiceSuccess, InvoiceReversed})
+	if got := fmt.Sprint(seen); got != want {
+		t.Fatalf("processed statuses = %s, want %s", got, want)
+	}
+}
+
+func TestWebhookProcessorReprocessesAfterCallbackError(t *testing.T) {
+	t.Parallel()
+
+	processor := NewWebhookProcessor(NewClient(), NewMemoryWebhookStore())
+	body := []byte(`{"invoiceId":"inv-1","status":"success","modifiedDate":"2026-02-26T10:00:00Z"}`)
+
+	_, err := processor.Handle(context.Background(), body, func(context.Context, *InvoiceStatusResponse) error {
+		return errors.New("db is down")
+	})
+	if err == nil {
+		t.Fatalf("expected callback error")
+	}
+
+	result, err := processor.Handle(context.Background(), body, nil)
+	if err != nil {
+		t.Fatalf("redelivery unexpected error: %v", err)
+	}
+	if !result.Processed {
+		t.Fatalf("redelivered event must be processed after failure, reason=%q", result.Reason)
+	}
+
+	if _, err := processor.Handle(context.Background(), []byte("{"), nil); !errors.Is(err, ErrDecode) {
+		t.Fatalf("expected ErrDecode for malformed body, got %v", err)
+	}
+}
+
+func TestFileWebhookStorePersistsStates(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	store, err := NewFileWebhookStore(path)
+	if err != nil {
+		t.Fatalf("NewFileWebhookStore() error: %v", err)
+	}
+
+	modified := time.Date(2026, 2, 26, 10, 0, 0, 0, time.UTC)
+	if err := store.Put(context.Background(), WebhookState{InvoiceID: "inv-1", Status: InvoiceSuccess, ModifiedDate: modified}); err != nil {
+		t.Fatalf("Put() error: %v", err)
+	}
+
+	reopened, err := NewFileWebhookStore(path)
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	state, err := reopened.Get(context.Background(), "inv-1")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if state == nil || state.Status != InvoiceSuccess || !state.ModifiedDate.Equal(modified) {
+		t.Fatalf("unexpected state after reopen: %+v", state)
+	}
+
+	result, err := NewWebhookProcessor(NewClient(), reopened).Process(context.Background(), webhookEvent(InvoiceProcessing, modified.Add(-time.Minute)), nil)
+	if err != nil {
+		t.Fatalf("Process() error: %v", err)
+	}
+	if result.Reason != WebhookDropStale {
+		t.Fatalf("reason = %q, want %q", result.Reason, WebhookDropStale)
+	}
+}
@@ -0,0 +1,173 @@
+package go_monobank
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+)
+
+// WebhookSigner produces X-Sign values the way monobank does (ECDSA P-256 over SHA-256 of body).
+//
+// It is meant for tests and local tools: pass PublicKeyBase64 to WithWebhookPublicKeyBase64
+// and send bodies signed by Sign/SignEvent to VerifyWebhook or WebhookHandler.
+// Use the constructors: the zero value has no key, Sign fails and PublicKeyPEM returns nil.
+type WebhookSigner struct {
+	key *ecdsa.PrivateKey
+	// publicPEM is encoded once by the constructor, which also validates the key.
+	publicPEM []byte
+}
+
+// NewWebhookSigner generates a new P-256 key.
+func NewWebhookSigner() (*WebhookSigner, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("webhook signer: generate key: %w", err)
+	}
+	return newWebhookSigner(key)
+}
+
+// NewWebhookSignerFromKey wraps an existing P-256 private key.
+// The public point must be valid and match the private scalar.
+func NewWebhookSignerFromKey(key *ecdsa.PrivateKey) (*WebhookSigner, error) {
+	if key == nil {
+		return nil, &ValidationError{Op: "webhookSigner", Msg: "key is nil"}
+	}
+	if key.Curve != elliptic.P256() {
+		return nil, &ValidationError{Op: "webhookSigner", Msg: "key must be on P-256 curve"}
+	}
+	if key.D == nil || key.X == nil || key.Y == nil {
+		return nil, &ValidationError{Op: "webhookSigner", Msg: "key is incomplete"}
+	}
+	priv, err := key.ECDH()
+	if err != nil {
+		return nil, &ValidationError{Op: "webhookSigner", Msg: "invalid private key", Cause: err}
+	}
+	pub, err := key.PublicKey.ECDH()
+	if err != nil {
+		return nil, &ValidationError{Op: "webhookSigner", Msg: "invalid public key", Cause: err}
+	}
+	if !priv.PublicKey().Equal(pub) {
+		return nil, &ValidationError{Op: "webhookSigner", Msg: "public key does not match private key"}
+	}
+	return newWebhookSigner(key)
+}
+
+func newWebhookSigner(key *ecdsa.PrivateKey) (*WebhookSigner, error) {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, &ValidationError{Op: "webhookSigner", Msg: "marshal public key", Cause: err}
+	}
+	return &WebhookSigner{key: key, publicPEM: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})}, nil
+}
+
+// LoadWebhookSignerPEM loads private key from PEM ("EC PRIVATE KEY" or PKCS#8 "PRIVATE KEY").
+func LoadWebhookSignerPEM(pemBytes []byte) (*WebhookSigner, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, &DecodeError{Op: "webhookSigner", Msg: "no PEM block found"}
+	}
+
+	var key *ecdsa.PrivateKey
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		parsed, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, &DecodeError{Op: "webhookSigner", Msg: "parse EC private key", Cause: err}
+		}
+		key = parsed
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, &DecodeError{Op: "webhookSigner", Msg: "parse PKCS#8 private key", Cause: err}
+		}
+		ecKey, ok := parsed.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, &DecodeError{Op: "webhookSigner", Msg: fmt.Sprintf("unexpected private key type %T (expected ECDSA)", parsed)}
+		}
+		key = ecKey
+	default:
+		return nil, &DecodeError{Op: "webhookSigner", Msg: fmt.Sprintf("unexpected PEM block type %q", block.Type)}
+	}
+	return NewWebhookSignerFromKey(key)
+}
+
+// PrivateKey returns the signing key.
+func (s *WebhookSigner) PrivateKey() *ecdsa.PrivateKey {
+	if s == nil {
+		return nil
+	}
+	return s.key
+}
+
+func (s *WebhookSigner) validate() error {
+	if s == nil || s.key == nil || len(s.publicPEM) == 0 {
+		return &ValidationError{Op: "webhookSigner", Msg: "signer has no key (use NewWebhookSigner or NewWebhookSignerFromKey)"}
+	}
+	return nil
+}
+
+// PrivateKeyPEM encodes the signing key as "EC PRIVATE KEY" PEM (loadable by LoadWebhookSignerPEM).
+func (s *WebhookSigner) PrivateKeyPEM() ([]byte, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(s.key)
+	if err != nil {
+		return nil, fmt.Errorf("webhook signer: marshal private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+// PublicKeyPEM encodes the public key as "PUBLIC KEY" PEM (see WithWebhookPublicKeyPEM).
+// It returns nil for a signer not created by a constructor.
+func (s *WebhookSigner) PublicKeyPEM() []byte {
+	if s == nil || len(s.publicPEM) == 0 {
+		return nil
+	}
+	return append([]byte(nil), s.publicPEM...)
+}
+
+// PublicKeyBase64 returns base64-encoded PEM public key, same format as PublicKeyResponse.Key
+// (see WithWebhookPublicKeyBase64). It returns "" for a signer not created by a constructor.
+func (s *WebhookSigner) PublicKeyBase64() string {
+	pemBytes := s.PublicKeyPEM()
+	if pemBytes == nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(pemBytes)
+}
+
+// Sign returns X-Sign header value for raw body.
+func (s *WebhookSigner) Sign(body []byte) (string, error) {
+	if err := s.validate(); err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(body)
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, h[:])
+	if err != nil {
+		return "", fmt.Errorf("webhook signer: sign: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// SignEvent marshals event to JSON and signs it. Send body as is: the signature covers exact bytes.
+func (s *WebhookSigner) SignEvent(event *InvoiceStatusResponse) (body []byte, xSign string, err error) {
+	if event == nil {
+		return nil, "", &ValidationError{Op: "webhookSigner", Msg: "event is nil"}
+	}
+	body, err = json.Marshal(event)
+	if err != nil {
+		return nil, "", fmt.Errorf("webhook signer: marshal event: %w", err)
+	}
+	xSign, err = s.Sign(body)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, xSign, nil
+}
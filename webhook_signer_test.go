@@ -0,0 +1,101 @@
+package go_monobank
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestWebhookSignerRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	signer, err := NewWebhookSigner()
+	if err != nil {
+		t.Fatalf("NewWebhookSigner() error: %v", err)
+	}
+	client := NewClient(WithWebhookPublicKeyBase64(signer.PublicKeyBase64()))
+
+	body, xSign, err := signer.SignEvent(&InvoiceStatusResponse{InvoiceID: "inv-1", Status: InvoiceSuccess, Amount: 100, Currency: CurrencyUAH})
+	if err != nil {
+		t.Fatalf("SignEvent() error: %v", err)
+	}
+	event, err := client.ParseAndVerifyWebhook(body, xSign)
+	if err != nil {
+		t.Fatalf("ParseAndVerifyWebhook() error: %v", err)
+	}
+	if event.InvoiceID != "inv-1" || event.Status != InvoiceSuccess {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	if err := client.VerifyWebhook(append(body, ' '), xSign); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for modified body, got %v", err)
+	}
+}
+
+func TestLoadWebhookSignerPEM(t *testing.T) {
+	t.Parallel()
+
+	signer, err := NewWebhookSigner()
+	if err != nil {
+		t.Fatalf("NewWebhookSigner() error: %v", err)
+	}
+	pemBytes, err := signer.PrivateKeyPEM()
+	if err != nil {
+		t.Fatalf("PrivateKeyPEM() error: %v", err)
+	}
+
+	loaded, err := LoadWebhookSignerPEM(pemBytes)
+	if err != nil {
+		t.Fatalf("LoadWebhookSignerPEM() error: %v", err)
+	}
+	if loaded.PublicKeyBase64() != signer.PublicKeyBase64() {
+		t.Fatalf("loaded signer has different public key")
+	}
+
+	if _, err := LoadWebhookSignerPEM([]byte("not a pem")); !errors.Is(err, ErrDecode) {
+		t.Fatalf("expected ErrDecode, got %v", err)
+	}
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	if _, err := NewWebhookSignerFromKey(p384); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation for P-384 key, got %v", err)
+	}
+}
+
+func TestWebhookSignerRejectsInvalidKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	broken := *key
+	broken.PublicKey.X = new(big.Int).Add(key.X, big.NewInt(1))
+	if _, err := NewWebhookSignerFromKey(&broken); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation for invalid public point, got %v", err)
+	}
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	mismatched := *key
+	mismatched.PublicKey = other.PublicKey
+	if _, err := NewWebhookSignerFromKey(&mismatched); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation for mismatched public key, got %v", err)
+	}
+
+	var zero WebhookSigner
+	if _, err := zero.Sign([]byte("{}")); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation from zero-value signer, got %v", err)
+	}
+	if zero.PublicKeyPEM() != nil || zero.PublicKeyBase64() != "" {
+		t.Fatalf("zero-value signer must have no public key")
+	}
+}
@@ -0,0 +1,209 @@
+package go_monobank
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// MemoryWebhookStore keeps webhook states in memory. It is safe for concurrent use.
+type MemoryWebhookStore struct {
+	mu     sync.RWMutex
+	states map[string]WebhookState
+}
+
+var _ WebhookStore = (*MemoryWebhookStore)(nil)
+
+// NewMemoryWebhookStore creates empty in-memory store.
+func NewMemoryWebhookStore() *MemoryWebhookStore {
+	return &MemoryWebhookStore{states: make(map[string]WebhookState)}
+}
+
+func (s *MemoryWebhookStore) Get(_ context.Context, invoiceID string) (*WebhookState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, ok := s.states[invoiceID]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (s *MemoryWebhookStore) Put(_ context.Context, state WebhookState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.InvoiceID] = state
+	return nil
+}
+
+// FileWebhookStore keeps webhook states in a JSON file, so deduplication survives restarts.
+// The whole file is rewritten atomically on every Put; use it for small/medium volumes
+// or as a reference for a database-backed store.
+type FileWebhookStore struct {
+	path string
+
+	mu     sync.Mutex
+	states map[string]WebhookState
+}
+
+var _ WebhookStore = (*FileWebhookStore)(nil)
+
+// NewFileWebhookStore opens (or creates on first Put) store at path.
+func NewFileWebhookStore(path string) (*FileWebhookStore, error) {
+	s := &FileWebhookStore{path: path, states: make(map[string]WebhookState)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("webhook store: read %s: %w", path, err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.states); err != nil {
+			return nil, &DecodeError{Op: "webhookStore", Msg: "json unmarshal " + path, Cause: err}
+		}
+	}
+	return s, nil
+}
+
+func (s *FileWebhookStore) Get(_ context.Context, invoiceID string) (*WebhookState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[invoiceID]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (s *FileWebhookStore) Put(_ context.Context, state WebhookState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.states[state.InvoiceID]
+	s.states[state.InvoiceID] = state
+	if err := s.flush(); err != nil {
+		if existed {
+			s.states[state.InvoiceID] = previous
+		} else {
+			delete(s.states, state.InvoiceID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *FileWebhookStore) flush() error {
+	data, err := json.Marshal(s.states)
+	if err != nil {
+		return &EncodeError{Op: "webhookStore", Msg: "json marshal", Cause: err}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("webhook store: create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("webhook store: write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("webhook store: close temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("webhook store: replace %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// DefaultRefundStoreSize is how many invoices NewMemoryRefundStore remembers by default.
+const DefaultRefundStoreSize = 10000
+
+// RefundStore remembers cancelList entries WebhookDispatcher already reported to OnReversed.
+// Keys are opaque identifiers of cancelList entries (extRef or createdDate/amount) and
+// of status=reversed itself.
+//
+// The default MemoryRefundStore lives in process memory: after a restart, or on another
+// replica, old refunds are reported again. Use a shared (database-backed) store when
+// OnReversed handlers are not idempotent.
+type RefundStore interface {
+	// Reported returns keys already reported for invoiceID.
+	Reported(ctx context.Context, invoiceID string) ([]string, error)
+	// MarkReported remembers keys as reported for invoiceID.
+	MarkReported(ctx context.Context, invoiceID string, keys []string) error
+}
+
+// MemoryRefundStore keeps reported refunds of the most recently updated invoices in memory.
+// It is safe for concurrent use.
+type MemoryRefundStore struct {
+	max int
+
+	mu       sync.Mutex
+	order    *list.List
+	invoices map[string]*list.Element
+}
+
+type memoryRefunds struct {
+	invoiceID string
+	keys      map[string]struct{}
+}
+
+var _ RefundStore = (*MemoryRefundStore)(nil)
+
+// NewMemoryRefundStore creates store remembering up to maxInvoices invoices; the least
+// recently updated invoice is evicted first. maxInvoices <= 0 means DefaultRefundStoreSize.
+func NewMemoryRefundStore(maxInvoices int) *MemoryRefundStore {
+	if maxInvoices <= 0 {
+		maxInvoices = DefaultRefundStoreSize
+	}
+	return &MemoryRefundStore{max: maxInvoices, order: list.New(), invoices: make(map[string]*list.Element)}
+}
+
+func (s *MemoryRefundStore) Reported(_ context.Context, invoiceID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.invoices[invoiceID]
+	if !ok {
+		return nil, nil
+	}
+	refunds := el.Value.(*memoryRefunds)
+	keys := make([]string, 0, len(refunds.keys))
+	for key := range refunds.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *MemoryRefundStore) MarkReported(_ context.Context, invoiceID string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.invoices[invoiceID]
+	if ok {
+		s.order.MoveToFront(el)
+	} else {
+		el = s.order.PushFront(&memoryRefunds{invoiceID: invoiceID, keys: make(map[string]struct{}, len(keys))})
+		s.invoices[invoiceID] = el
+		for s.order.Len() > s.max {
+			oldest := s.order.Back()
+			s.order.Remove(oldest)
+			delete(s.invoices, oldest.Value.(*memoryRefunds).invoiceID)
+		}
+	}
+	refunds := el.Value.(*memoryRefunds)
+	for _, key := range keys {
+		refunds.keys[key] = struct{}{}
+	}
+	return nil
+}